@@ -1,14 +1,25 @@
 package main
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
+	"portexec/internal/export"
 	"portexec/internal/killer"
+	"portexec/internal/metrics"
 	"portexec/internal/models"
+	"portexec/internal/policy"
 	"portexec/internal/ports"
+	"portexec/internal/processes"
+	"portexec/internal/rpc"
 	"portexec/internal/tui"
 	"portexec/internal/version"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -29,17 +40,46 @@ func main() {
 			os.Exit(0)
 		}
 
+		// Non-interactive export mode: portexec --format=json|csv|prom
+		if format, ok := parseFormatFlag(os.Args[1:]); ok {
+			if err := runExport(format); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		// Headless rule-engine mode: portexec --daemon [--interval=30s] [--rules=path]
+		if os.Args[1] == "--daemon" {
+			if err := runDaemon(os.Args[1:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
 		// Check for CLI mode commands
 		cliCommands := map[string]bool{
-			"list":  true,
-			"kill":  true,
-			"check": true,
+			"list":    true,
+			"kill":    true,
+			"check":   true,
+			"serve":   true,
+			"daemon":  true,
+			"watch":   true,
+			"enforce": true,
+			"metrics": true,
 		}
 
 		if cliCommands[os.Args[1]] {
 			runCLI()
 			return
 		}
+
+		// Remote TUI mode: portexec --remote host:port
+		if remote, ok := parseRemoteFlag(os.Args[1:]); ok {
+			runRemoteTUI(remote)
+			return
+		}
 	}
 
 	// Run TUI mode
@@ -54,6 +94,53 @@ func runTUI() {
 	}
 }
 
+// parseRemoteFlag extracts a --remote=<host:port> flag from args.
+func parseRemoteFlag(args []string) (string, bool) {
+	for _, a := range args {
+		if strings.HasPrefix(a, "--remote=") {
+			return strings.TrimPrefix(a, "--remote="), true
+		}
+	}
+	return "", false
+}
+
+// runRemoteTUI dials addr and starts the TUI against the remote host's
+// scanner/killer instead of the local machine's. Unknown server
+// certificates are confirmed on the terminal, mirroring an SSH
+// known_hosts prompt.
+func runRemoteTUI(addr string) {
+	known, err := rpc.LoadKnownHosts()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading known_hosts: %v\n", err)
+		os.Exit(1)
+	}
+
+	client, err := rpc.DialTLS(addr, known, promptTrust)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error connecting to %s: %v\n", addr, err)
+		os.Exit(1)
+	}
+	defer client.Close()
+
+	p := tea.NewProgram(tui.NewRemoteModel(client, client), tea.WithAltScreen())
+	if err := p.Start(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error starting application: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// promptTrust asks the operator on stdin/stdout whether to trust and
+// pin a never-before-seen server certificate fingerprint.
+func promptTrust(host, fingerprint string) bool {
+	fmt.Printf("The authenticity of host '%s' can't be established.\n", host)
+	fmt.Printf("Certificate fingerprint is SHA256:%s.\n", fingerprint)
+	fmt.Print("Are you sure you want to continue connecting (yes/no)? ")
+
+	reader := bufio.NewReader(os.Stdin)
+	answer, _ := reader.ReadString('\n')
+	return strings.TrimSpace(strings.ToLower(answer)) == "yes"
+}
+
 func runCLI() {
 	rootCmd := &cobra.Command{
 		Use:   "portexec",
@@ -62,6 +149,7 @@ func runCLI() {
 	}
 
 	// List command
+	var listRemote, listOutput string
 	listCmd := &cobra.Command{
 		Use:   "list [port]",
 		Short: "List processes on ports",
@@ -71,39 +159,144 @@ func runCLI() {
 			if len(args) > 0 {
 				port = args[0]
 			}
-			return listPorts(port, listenFlag)
+			return listPorts(port, listenFlag, listRemote, listOutput)
 		},
 	}
 	listCmd.Flags().BoolVarP(&listenFlag, "listen", "l", false, "Show only listening ports")
+	listCmd.Flags().StringVar(&listRemote, "remote", "", `Query a "portexec daemon" socket instead of scanning locally (e.g. unix:///var/run/portexec.sock)`)
+	listCmd.Flags().StringVar(&listOutput, "output", "table", "Output format: table, json, ndjson, or csv")
 	rootCmd.AddCommand(listCmd)
 
 	// Kill command
+	var killRemote string
+	var killTree bool
 	killCmd := &cobra.Command{
 		Use:   "kill <port>",
 		Short: "Kill process on a port",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return killByPort(args[0])
+			if killTree {
+				return killPortTree(args[0])
+			}
+			return killByPort(args[0], killRemote)
 		},
 	}
+	killCmd.Flags().StringVar(&killRemote, "remote", "", `Kill through a "portexec daemon" socket instead of locally`)
+	killCmd.Flags().BoolVar(&killTree, "tree", false, "Also kill every descendant of the process bound to the port")
 	rootCmd.AddCommand(killCmd)
 
 	// Check admin command
+	var checkOutput string
 	checkCmd := &cobra.Command{
 		Use:   "check",
 		Short: "Check if running as administrator",
 		Run: func(cmd *cobra.Command, args []string) {
-			if killer.IsElevated() {
-				fmt.Println("Running with administrator privileges")
-				os.Exit(0)
-			} else {
-				fmt.Println("NOT running with administrator privileges")
+			elevated := killer.IsElevated()
+
+			switch checkOutput {
+			case "json", "ndjson":
+				_ = json.NewEncoder(os.Stdout).Encode(map[string]bool{"elevated": elevated})
+			default:
+				if elevated {
+					fmt.Println("Running with administrator privileges")
+				} else {
+					fmt.Println("NOT running with administrator privileges")
+				}
+			}
+
+			if !elevated {
 				os.Exit(1)
 			}
 		},
 	}
+	checkCmd.Flags().StringVar(&checkOutput, "output", "table", "Output format: table or json")
 	rootCmd.AddCommand(checkCmd)
 
+	// Serve command
+	var listenAddr, tlsCert, tlsKey string
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the local port scanner over mTLS for remote TUI/CLI access",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if tlsCert == "" || tlsKey == "" {
+				return fmt.Errorf("--tls-cert and --tls-key are required")
+			}
+			scanner := ports.NewScanner()
+			k := killer.NewKiller()
+			k.SetPolicy(loadPolicyEngine())
+			fmt.Printf("PortExec serving on %s\n", listenAddr)
+			return rpc.Serve(listenAddr, tlsCert, tlsKey, scanner, k)
+		},
+	}
+	serveCmd.Flags().StringVar(&listenAddr, "listen", ":9443", "Address to listen on")
+	serveCmd.Flags().StringVar(&tlsCert, "tls-cert", "", "Path to the server TLS certificate")
+	serveCmd.Flags().StringVar(&tlsKey, "tls-key", "", "Path to the server TLS private key")
+	rootCmd.AddCommand(serveCmd)
+
+	// Daemon command
+	var daemonAddr string
+	daemonCmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Run a long-lived local daemon over a Unix socket (named pipe on Windows) for unprivileged clients",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scanner := ports.NewScanner()
+			k := killer.NewKiller()
+			k.SetPolicy(loadPolicyEngine())
+			fmt.Printf("PortExec daemon listening on %s (elevated: %v)\n", daemonAddr, killer.IsElevated())
+			return rpc.ServeLocal(daemonAddr, scanner, k, killer.IsElevated())
+		},
+	}
+	daemonCmd.Flags().StringVar(&daemonAddr, "socket", rpc.DefaultSocketAddr, "Unix socket (or named pipe, on Windows) to listen on")
+	rootCmd.AddCommand(daemonCmd)
+
+	// Watch command
+	var watchJSON bool
+	var watchInterval time.Duration
+	watchCmd := &cobra.Command{
+		Use:   "watch [port]",
+		Short: "Stream port added/removed/state-changed events as they occur",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			port := ""
+			if len(args) > 0 {
+				port = args[0]
+			}
+			return runWatch(port, watchJSON, watchInterval)
+		},
+	}
+	watchCmd.Flags().BoolVar(&watchJSON, "json", false, "Emit newline-delimited JSON instead of text")
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 2*time.Second, "Polling interval")
+	rootCmd.AddCommand(watchCmd)
+
+	// Enforce command
+	var enforceWatch, enforceDryRun bool
+	var enforcePolicyPath string
+	var enforceInterval time.Duration
+	enforceCmd := &cobra.Command{
+		Use:   "enforce",
+		Short: "Apply policy rules to currently open ports, once or continuously",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEnforce(enforcePolicyPath, enforceWatch, enforceDryRun, enforceInterval)
+		},
+	}
+	enforceCmd.Flags().BoolVar(&enforceWatch, "watch", false, "Keep running, reacting to newly opened ports instead of exiting after one pass")
+	enforceCmd.Flags().BoolVar(&enforceDryRun, "dry-run", false, "Log what would be enforced without killing anything")
+	enforceCmd.Flags().StringVar(&enforcePolicyPath, "policy", "", "Path to the policy file (default ~/.portexec/policy.yaml)")
+	enforceCmd.Flags().DurationVar(&enforceInterval, "interval", 2*time.Second, "Polling interval used with --watch")
+	rootCmd.AddCommand(enforceCmd)
+
+	// Metrics command
+	var metricsListen string
+	metricsCmd := &cobra.Command{
+		Use:   "metrics",
+		Short: "Serve a Prometheus scrape endpoint over the current port snapshot",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMetrics(metricsListen)
+		},
+	}
+	metricsCmd.Flags().StringVar(&metricsListen, "listen", ":9315", "Address to listen on")
+	rootCmd.AddCommand(metricsCmd)
+
 	// Flags
 	rootCmd.PersistentFlags().BoolVarP(&showPathFlag, "path", "p", false, "Show executable path")
 
@@ -113,8 +306,18 @@ func runCLI() {
 	}
 }
 
-func listPorts(port string, listenOnly bool) error {
-	scanner := ports.NewScanner()
+func listPorts(port string, listenOnly bool, remote string, output string) error {
+	var scanner ports.PortSource
+	if remote != "" {
+		client, err := rpc.DialLocal(remote)
+		if err != nil {
+			return fmt.Errorf("failed to connect to daemon at %s: %w", remote, err)
+		}
+		defer client.Close()
+		scanner = client
+	} else {
+		scanner = ports.NewScanner()
+	}
 
 	var states []string
 	if listenOnly {
@@ -137,6 +340,10 @@ func listPorts(port string, listenOnly bool) error {
 		entries = filtered
 	}
 
+	if format := export.Format(output); format != "" && format != "table" {
+		return export.Write(os.Stdout, format, entries)
+	}
+
 	// Print header
 	fmt.Printf("%-6s %-6s %-6s %-20s %-12s\n", "PROTO", "PORT", "PID", "PROCESS", "STATE")
 	fmt.Println(strings.Repeat("-", 60))
@@ -154,9 +361,25 @@ func listPorts(port string, listenOnly bool) error {
 	return nil
 }
 
-func killByPort(port string) error {
+func killByPort(port string, remote string) error {
+	if remote != "" {
+		client, err := rpc.DialLocal(remote)
+		if err != nil {
+			return fmt.Errorf("failed to connect to daemon at %s: %w", remote, err)
+		}
+		defer client.Close()
+
+		result := client.KillByPort(parsePort(port))
+		fmt.Println(result.Message)
+		if !result.Success {
+			os.Exit(1)
+		}
+		return nil
+	}
+
 	scanner := ports.NewScanner()
 	k := killer.NewKiller()
+	k.SetPolicy(loadPolicyEngine())
 
 	// Get entries for the port
 	entries, err := scanner.GetEntriesByPort(parsePort(port))
@@ -197,6 +420,299 @@ func killByPort(port string) error {
 	return nil
 }
 
+// killPortTree finds the process(es) bound to port and kills each one
+// along with its full descendant tree, printing the tree that will be
+// affected before confirming.
+func killPortTree(port string) error {
+	scanner := ports.NewScanner()
+	getter := processes.NewGetter()
+	k := killer.NewKiller()
+	k.SetPolicy(loadPolicyEngine())
+
+	entries, err := scanner.GetEntriesByPort(parsePort(port))
+	if err != nil {
+		return fmt.Errorf("failed to get port entries: %w", err)
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("no process found on port %s", port)
+	}
+
+	pidMap := make(map[int32]models.PortEntry)
+	for _, e := range entries {
+		pidMap[e.PID] = e
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	hasError := false
+	for pid, entry := range pidMap {
+		fmt.Printf("%s (PID: %d) and its descendants:\n", entry.ProcessName, pid)
+		printProcessTree(getter, pid, "  ")
+
+		fmt.Print("Kill these processes (yes/no)? ")
+		answer, _ := reader.ReadString('\n')
+		if a := strings.TrimSpace(strings.ToLower(answer)); a != "yes" && a != "y" {
+			fmt.Println("  skipped")
+			continue
+		}
+
+		results := k.KillTree(pid, killer.TreeKillOptions{IncludeParent: true, GracePeriod: 3 * time.Second})
+		for _, r := range results {
+			fmt.Println(" ", r.Message)
+			if !r.Success {
+				hasError = true
+			}
+		}
+	}
+
+	if hasError {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+// loadPolicyEngine best-effort loads the default policy config, so a
+// plain CLI kill also honors any warn rules the user has configured.
+// Returns nil (disabling enforcement) if no config file exists yet.
+func loadPolicyEngine() *policy.Engine {
+	path, err := policy.DefaultPath()
+	if err != nil {
+		return nil
+	}
+	engine, err := policy.Load(path)
+	if err != nil {
+		return nil
+	}
+	return engine
+}
+
+// printProcessTree prints pid's name and descendants, indenting one
+// level per generation.
+func printProcessTree(getter *processes.Getter, pid int32, indent string) {
+	name, err := getter.GetProcessName(pid)
+	if err != nil {
+		name = "unknown"
+	}
+	fmt.Printf("%s%s (PID: %d)\n", indent, name, pid)
+
+	children, err := getter.GetChildren(pid)
+	if err != nil {
+		return
+	}
+	for _, c := range children {
+		printProcessTree(getter, c, indent+"  ")
+	}
+}
+
+// runDaemon is the legacy `--daemon [--interval=30s] [--policy=path]`
+// entry point, kept for backward compatibility. It's now a thin
+// wrapper around runEnforce's --watch mode — the policy engine used to
+// be a separate "rules" engine with its own config and hit-or-miss
+// enforcement, but they've been merged into one engine so there's a
+// single place (~/.portexec/policy.yaml, runEnforce) that decides what
+// happens to a matching process.
+func runDaemon(args []string) error {
+	interval := 30 * time.Second
+	policyPath := ""
+
+	for _, a := range args[1:] {
+		switch {
+		case strings.HasPrefix(a, "--interval="):
+			d, err := time.ParseDuration(strings.TrimPrefix(a, "--interval="))
+			if err != nil {
+				return fmt.Errorf("invalid --interval: %w", err)
+			}
+			interval = d
+		case strings.HasPrefix(a, "--policy="):
+			policyPath = strings.TrimPrefix(a, "--policy=")
+		case strings.HasPrefix(a, "--rules="): // old flag name, same meaning
+			policyPath = strings.TrimPrefix(a, "--rules=")
+		}
+	}
+
+	return runEnforce(policyPath, true, false, interval)
+}
+
+// runMetrics serves a Prometheus scrape endpoint at /metrics on
+// listenAddr, backed by the local scanner.
+func runMetrics(listenAddr string) error {
+	scanner := ports.NewScanner()
+	http.Handle("/metrics", metrics.NewHandler(scanner))
+	fmt.Printf("PortExec metrics serving on %s/metrics\n", listenAddr)
+	return http.ListenAndServe(listenAddr, nil)
+}
+
+// runEnforce loads the policy config and applies it to currently open
+// ports, either once or (with watch) continuously by reacting to
+// events from Scanner.Watch. Every decision, including dry-run ones, is
+// recorded to the audit log.
+func runEnforce(policyPath string, watch bool, dryRun bool, interval time.Duration) error {
+	if policyPath == "" {
+		p, err := policy.DefaultPath()
+		if err != nil {
+			return fmt.Errorf("failed to resolve default policy path: %w", err)
+		}
+		policyPath = p
+	}
+
+	engine, err := policy.Load(policyPath)
+	if err != nil {
+		return fmt.Errorf("failed to load policy from %s: %w", policyPath, err)
+	}
+
+	auditPath, err := policy.DefaultAuditPath()
+	if err != nil {
+		return fmt.Errorf("failed to resolve audit log path: %w", err)
+	}
+	audit, err := policy.NewAuditLogger(auditPath)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer audit.Close()
+
+	scanner := ports.NewScanner()
+	k := killer.NewKiller()
+
+	if !watch {
+		entries, err := scanner.GetListeningPorts()
+		if err != nil {
+			return fmt.Errorf("failed to get listening ports: %w", err)
+		}
+		for _, match := range engine.Evaluate(entries) {
+			enforcePolicyMatch(k, audit, match, dryRun)
+		}
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	events, err := scanner.Watch(ctx, interval)
+	if err != nil {
+		return fmt.Errorf("failed to start watch: %w", err)
+	}
+
+	fmt.Printf("PortExec enforce --watch started (policy=%s dry-run=%v)\n", policyPath, dryRun)
+
+	for event := range events {
+		if event.Type == ports.PortEventRemoved {
+			continue
+		}
+		if match, ok := engine.MatchOne(event.Entry); ok {
+			enforcePolicyMatch(k, audit, match, dryRun)
+		}
+	}
+
+	return nil
+}
+
+// enforcePolicyMatch applies match's rule action, printing the outcome
+// and recording a structured policy.Decision regardless of whether this
+// is a dry run.
+func enforcePolicyMatch(k *killer.Killer, audit *policy.AuditLogger, match policy.Match, dryRun bool) {
+	outcome := "applied"
+	if dryRun {
+		outcome = "dry-run"
+	}
+
+	switch match.Rule.Action {
+	case policy.ActionKill:
+		if match.Entry.IsSystem {
+			outcome = "skipped: critical process"
+			fmt.Printf("[enforce] refusing to kill critical process %s (PID %d)\n", match.Entry.ProcessName, match.Entry.PID)
+		} else if dryRun {
+			fmt.Printf("[enforce] dry-run: would kill %s (PID %d) on port %d\n", match.Entry.ProcessName, match.Entry.PID, match.Entry.Port)
+		} else {
+			result := k.Kill(match.Entry.PID)
+			outcome = result.Message
+			fmt.Printf("[enforce] kill %s (PID %d): %s\n", match.Entry.ProcessName, match.Entry.PID, result.Message)
+		}
+
+	case policy.ActionWarn:
+		fmt.Printf("[enforce] warn: %s (PID %d) on port %d matched policy rule %d\n", match.Entry.ProcessName, match.Entry.PID, match.Entry.Port, match.RuleIndex)
+
+	case policy.ActionAlert:
+		fmt.Printf("[enforce] alert: %s (PID %d) on port %d matched policy rule %d\n", match.Entry.ProcessName, match.Entry.PID, match.Entry.Port, match.RuleIndex)
+		if err := policy.Notify(match.Rule, match.Entry); err != nil {
+			fmt.Fprintf(os.Stderr, "[enforce] webhook failed: %v\n", err)
+		}
+
+	case policy.ActionIgnore:
+		outcome = "ignored"
+	}
+
+	if err := audit.Log(policy.Decision{
+		Action:    match.Rule.Action,
+		RuleIndex: match.RuleIndex,
+		DryRun:    dryRun,
+		Outcome:   outcome,
+		Entry:     match.Entry,
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "[enforce] failed to write audit log: %v\n", err)
+	}
+}
+
+// parseFormatFlag extracts a --format=<value> flag from args, returning
+// ok=false when the flag is absent so callers fall through to TUI/CLI mode.
+func parseFormatFlag(args []string) (export.Format, bool) {
+	for _, a := range args {
+		if strings.HasPrefix(a, "--format=") {
+			return export.Format(strings.TrimPrefix(a, "--format=")), true
+		}
+	}
+	return "", false
+}
+
+// runExport snapshots the current listening ports and writes them to
+// stdout in the requested format, without starting the TUI.
+func runExport(format export.Format) error {
+	scanner := ports.NewScanner()
+
+	entries, err := scanner.GetListeningPorts()
+	if err != nil {
+		return fmt.Errorf("failed to get listening ports: %w", err)
+	}
+
+	return export.Write(os.Stdout, format, entries)
+}
+
+// runWatch streams port events as they happen, optionally filtered to a
+// single port, until interrupted with Ctrl+C.
+func runWatch(port string, asJSON bool, interval time.Duration) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	scanner := ports.NewScanner()
+	events, err := scanner.Watch(ctx, interval)
+	if err != nil {
+		return fmt.Errorf("failed to start watch: %w", err)
+	}
+
+	var filterPort uint32
+	if port != "" {
+		filterPort = parsePort(port)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	for event := range events {
+		if filterPort != 0 && event.Entry.Port != filterPort {
+			continue
+		}
+
+		if asJSON {
+			if err := enc.Encode(event); err != nil {
+				return fmt.Errorf("failed to encode event: %w", err)
+			}
+			continue
+		}
+
+		fmt.Printf("%-14s %-6s %-6d %-20s PID %d (%s)\n",
+			event.Type, event.Entry.Protocol, event.Entry.Port, event.Entry.ProcessName, event.Entry.PID, event.Entry.State)
+	}
+
+	return nil
+}
+
 func parsePort(port string) uint32 {
 	var p uint32
 	fmt.Sscanf(port, "%d", &p)
@@ -207,13 +723,31 @@ func printUsage() {
 	fmt.Printf("PortExec v%s - Port Process Management Tool\n\n", version.Version)
 	fmt.Print(`Usage:
   portexec                    Start interactive TUI
+  portexec --format=<fmt>     Export a port snapshot and exit (json, csv, prom)
+  portexec --daemon           Run the rule engine headless (no TUI)
+  portexec --remote=<addr>    Start the TUI against a remote "portexec serve" host
   portexec list [port]       List processes on ports
   portexec kill <port>       Kill process on port
   portexec check             Check admin privileges
+  portexec serve             Serve the local scanner over mTLS
+  portexec daemon            Run a local Unix-socket daemon for unprivileged clients
+  portexec watch [port]      Stream port added/removed/state-changed events
+  portexec enforce           Apply ~/.portexec/policy.yaml to currently open ports
+  portexec enforce --watch   Keep enforcing policy as ports open, reacting live
+  portexec metrics           Serve a Prometheus scrape endpoint over the port snapshot
 
 Options:
-  -l, --listen    Show only listening ports
-  -p, --path      Show executable path
+  -l, --listen      Show only listening ports
+  -p, --path        Show executable path
+      --remote      (list/kill) Talk to a "portexec daemon" socket instead of scanning locally
+      --tree        (kill) Also kill every descendant of the process bound to the port
+      --output      (list/check) Output format: table, json, ndjson, or csv (check: table or json)
+      --json        (watch) Emit newline-delimited JSON instead of text
+      --interval    (watch/enforce --watch) Polling interval (default 2s)
+      --watch       (enforce) React to newly opened ports instead of exiting after one pass
+      --dry-run     (enforce) Log what would be enforced without killing anything
+      --policy      (enforce) Path to the policy file (default ~/.portexec/policy.yaml)
+      --listen      (metrics) Address to listen on (default :9315)
 
 Keyboard Shortcuts (TUI mode):
   ↑/↓ or j/k    Navigate list
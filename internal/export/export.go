@@ -0,0 +1,147 @@
+// Package export serializes port snapshots for non-interactive consumption
+// by scripts, spreadsheets, and monitoring pipelines.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"portexec/internal/models"
+	"strconv"
+)
+
+// Format identifies an output encoding for a port snapshot.
+type Format string
+
+const (
+	FormatJSON   Format = "json"
+	FormatNDJSON Format = "ndjson"
+	FormatCSV    Format = "csv"
+	FormatProm   Format = "prom"
+)
+
+// entry is the JSON-facing shape of models.PortEntry, with Uptime
+// normalized to whole seconds so consumers don't need to parse Go
+// duration strings.
+type entry struct {
+	Protocol    string `json:"protocol"`
+	Port        uint32 `json:"port"`
+	PID         int32  `json:"pid"`
+	ProcessName string `json:"process_name"`
+	State       string `json:"state"`
+	ParentPID   int32  `json:"parent_pid"`
+	UptimeSecs  int64  `json:"uptime_seconds"`
+	ExePath     string `json:"exe_path"`
+	IsSystem    bool   `json:"is_system"`
+}
+
+func toEntry(e models.PortEntry) entry {
+	return entry{
+		Protocol:    e.Protocol,
+		Port:        e.Port,
+		PID:         e.PID,
+		ProcessName: e.ProcessName,
+		State:       e.State,
+		ParentPID:   e.ParentPID,
+		UptimeSecs:  int64(e.Uptime.Seconds()),
+		ExePath:     e.ExePath,
+		IsSystem:    e.IsSystem,
+	}
+}
+
+// Write encodes entries in the given format to w.
+func Write(w io.Writer, format Format, entries []models.PortEntry) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, entries)
+	case FormatNDJSON:
+		return writeNDJSON(w, entries)
+	case FormatCSV:
+		return writeCSV(w, entries)
+	case FormatProm:
+		return writeProm(w, entries)
+	default:
+		return fmt.Errorf("unsupported export format: %q", format)
+	}
+}
+
+func writeJSON(w io.Writer, entries []models.PortEntry) error {
+	out := make([]entry, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, toEntry(e))
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// writeNDJSON emits one compact JSON object per entry, newline-delimited,
+// so a long-running consumer (e.g. `portexec list --output ndjson | jq`)
+// can process entries as they're written instead of waiting for a
+// closing array bracket.
+func writeNDJSON(w io.Writer, entries []models.PortEntry) error {
+	enc := json.NewEncoder(w)
+	for _, e := range entries {
+		if err := enc.Encode(toEntry(e)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCSV(w io.Writer, entries []models.PortEntry) error {
+	cw := csv.NewWriter(w)
+	defer cw.Flush()
+
+	header := []string{"protocol", "port", "pid", "process_name", "state", "parent_pid", "uptime_seconds", "exe_path", "is_system"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		row := []string{
+			e.Protocol,
+			strconv.FormatUint(uint64(e.Port), 10),
+			strconv.FormatInt(int64(e.PID), 10),
+			e.ProcessName,
+			e.State,
+			strconv.FormatInt(int64(e.ParentPID), 10),
+			strconv.FormatInt(int64(e.Uptime.Seconds()), 10),
+			e.ExePath,
+			strconv.FormatBool(e.IsSystem),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return cw.Error()
+}
+
+// writeProm emits a single gauge, one line per entry, so node_exporter's
+// textfile collector can scrape a file this is written to on a cron.
+func writeProm(w io.Writer, entries []models.PortEntry) error {
+	if _, err := fmt.Fprintln(w, "# HELP portexec_port_listening Whether a port was observed listening at scrape time (always 1)."); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "# TYPE portexec_port_listening gauge"); err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		_, err := fmt.Fprintf(w, "portexec_port_listening{proto=%q,port=%q,pid=%q,process=%q,state=%q} 1\n",
+			e.Protocol,
+			strconv.FormatUint(uint64(e.Port), 10),
+			strconv.FormatInt(int64(e.PID), 10),
+			e.ProcessName,
+			e.State,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"portexec/internal/models"
+)
+
+func sampleEntries() []models.PortEntry {
+	return []models.PortEntry{
+		{
+			Protocol:    "TCP",
+			Port:        8080,
+			PID:         1234,
+			ProcessName: "nginx",
+			State:       "LISTENING",
+			ParentPID:   1,
+			Uptime:      90 * time.Second,
+			ExePath:     "/usr/sbin/nginx",
+			IsSystem:    false,
+		},
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSON, sampleEntries()); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	var out []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("output isn't valid JSON: %v", err)
+	}
+	if len(out) != 1 {
+		t.Fatalf("got %d entries, want 1", len(out))
+	}
+	if got := out[0]["uptime_seconds"]; got != float64(90) {
+		t.Errorf("uptime_seconds = %v, want 90 (normalized from time.Duration)", got)
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	entries := append(sampleEntries(), sampleEntries()[0])
+
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatNDJSON, entries); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != len(entries) {
+		t.Fatalf("got %d lines, want %d (one object per entry)", len(lines), len(entries))
+	}
+	for _, line := range lines {
+		var obj map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &obj); err != nil {
+			t.Errorf("line %q isn't a single JSON object: %v", line, err)
+		}
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatCSV, sampleEntries()); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2 (header + one row)", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "protocol,port,pid") {
+		t.Errorf("header = %q, want it to start with the column names", lines[0])
+	}
+	if !strings.Contains(lines[1], "nginx") {
+		t.Errorf("row = %q, want it to contain the process name", lines[1])
+	}
+}
+
+func TestWriteProm(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatProm, sampleEntries()); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `proto="TCP"`) || !strings.Contains(out, `port="8080"`) {
+		t.Errorf("output missing expected labels: %s", out)
+	}
+	if !strings.Contains(out, "# TYPE portexec_port_listening gauge") {
+		t.Errorf("output missing TYPE comment: %s", out)
+	}
+}
+
+func TestWriteUnsupportedFormat(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, Format("yaml"), sampleEntries()); err == nil {
+		t.Fatal("Write with an unsupported format returned no error")
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"os"
 	"portexec/internal/models"
+	"portexec/internal/policy"
+	"portexec/internal/processes"
+	"time"
 
 	"github.com/shirou/gopsutil/v3/process"
 )
@@ -16,11 +19,27 @@ type Result struct {
 }
 
 // Killer handles safe process termination
-type Killer struct{}
+type Killer struct {
+	getter *processes.Getter
+	policy *policy.Engine
+}
+
+// Terminator abstracts process termination so callers can be pointed
+// at a local Killer or a remote RPC client interchangeably.
+type Terminator interface {
+	Kill(pid int32) Result
+}
 
 // NewKiller creates a new killer instance
 func NewKiller() *Killer {
-	return &Killer{}
+	return &Killer{getter: processes.NewGetter()}
+}
+
+// SetPolicy attaches a policy engine that Kill consults before acting,
+// in addition to the built-in IsCriticalProcess check. Passing nil
+// disables policy enforcement.
+func (k *Killer) SetPolicy(engine *policy.Engine) {
+	k.policy = engine
 }
 
 // Kill attempts to terminate a process by PID
@@ -43,6 +62,21 @@ func (k *Killer) Kill(pid int32) Result {
 		}
 	}
 
+	// Consult the policy engine, if one is attached. Kill only has a
+	// PID and process name to go on (no port), so port-scoped rules
+	// can't be evaluated here; only rules that match on process name
+	// alone apply to this path.
+	if k.policy != nil {
+		entry := models.PortEntry{PID: pid, ProcessName: procName}
+		if match, ok := k.policy.MatchOne(entry); ok && match.Rule.Action == policy.ActionWarn {
+			return Result{
+				Success: false,
+				Message: fmt.Sprintf("Refusing to kill %s (PID %d): protected by policy rule %d (warn)", procName, pid, match.RuleIndex),
+				Error:   fmt.Errorf("protected by policy"),
+			}
+		}
+	}
+
 	// Try to get the process
 	p, err := process.NewProcess(pid)
 	if err != nil {
@@ -120,6 +154,150 @@ func (k *Killer) ForceKill(pid int32) Result {
 	}
 }
 
+// KillSubtree terminates pid and all of its descendant processes. It is
+// a thin wrapper around KillTree (see that doc for the shared tree-walk
+// and safety semantics), kept as a separate entry point for callers
+// that want the old "kill everything now, no grace period" behavior.
+func (k *Killer) KillSubtree(pid int32) []Result {
+	return k.KillTree(pid, TreeKillOptions{IncludeParent: true})
+}
+
+// TreeKillOptions configures a Killer.KillTree invocation.
+type TreeKillOptions struct {
+	// IncludeParent also kills pid itself, not just its descendants.
+	IncludeParent bool
+	// MaxDepth limits how many generations of descendants are walked.
+	// Zero means unlimited depth.
+	MaxDepth int
+	// GracePeriod is how long to wait after a graceful Terminate before
+	// escalating to a forceful Kill. Zero escalates immediately.
+	GracePeriod time.Duration
+}
+
+// KillTree terminates pid's descendants (and pid itself, if
+// opts.IncludeParent), walking the tree built from
+// processes.Getter.GetChildren — the single, OS-agnostic child-discovery
+// mechanism used for every tree-kill in PortExec (the TUI's 'K' key and
+// the CLI's `kill --tree` both end up here). If any process in the tree
+// is a critical system process, the whole operation is aborted and
+// nothing is killed, matching the safety guarantee of a plain Kill.
+// Each surviving process is sent a graceful Terminate first and only
+// force-killed after opts.GracePeriod elapses (zero escalates
+// immediately), and is subject to the same policy check as Kill.
+func (k *Killer) KillTree(pid int32, opts TreeKillOptions) []Result {
+	descendants, err := k.collectTree(pid, opts.MaxDepth)
+	if err != nil {
+		return []Result{{
+			Success: false,
+			Message: fmt.Sprintf("Failed to walk process tree for %d: %v", pid, err),
+			Error:   err,
+		}}
+	}
+
+	if opts.IncludeParent {
+		descendants = append(descendants, pid)
+	}
+
+	for _, p := range descendants {
+		name, _ := k.getProcessName(p)
+		if models.IsCriticalProcess(name) {
+			return []Result{{
+				Success: false,
+				Message: fmt.Sprintf("Refusing to kill tree: critical system process %s (PID %d) found", name, p),
+				Error:   fmt.Errorf("critical system process in tree"),
+			}}
+		}
+	}
+
+	results := make([]Result, 0, len(descendants))
+	for _, p := range descendants {
+		results = append(results, k.killGraceful(p, opts.GracePeriod))
+	}
+	return results
+}
+
+// collectTree returns pid's descendants in post-order (leaves first),
+// stopping after maxDepth generations (0 = unlimited).
+func (k *Killer) collectTree(pid int32, maxDepth int) ([]int32, error) {
+	var order []int32
+
+	var walk func(p int32, depth int) error
+	walk = func(p int32, depth int) error {
+		if maxDepth > 0 && depth >= maxDepth {
+			return nil
+		}
+		children, err := k.getter.GetChildren(p)
+		if err != nil {
+			return err
+		}
+		for _, c := range children {
+			if err := walk(c, depth+1); err != nil {
+				return err
+			}
+			order = append(order, c)
+		}
+		return nil
+	}
+
+	if err := walk(pid, 0); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// killGraceful sends pid a Terminate, waits up to grace for it to
+// exit, and escalates to Kill if it's still running afterward. It
+// applies the same safety checks as Kill (critical-process refusal and
+// policy enforcement), so a tree-kill honors the same protections a
+// single kill does.
+func (k *Killer) killGraceful(pid int32, grace time.Duration) Result {
+	name, _ := k.getProcessName(pid)
+	if models.IsCriticalProcess(name) {
+		return Result{
+			Success: false,
+			Message: fmt.Sprintf("Skipped critical system process: %s (PID %d)", name, pid),
+			Error:   fmt.Errorf("critical system process"),
+		}
+	}
+
+	if k.policy != nil {
+		entry := models.PortEntry{PID: pid, ProcessName: name}
+		if match, ok := k.policy.MatchOne(entry); ok && match.Rule.Action == policy.ActionWarn {
+			return Result{
+				Success: false,
+				Message: fmt.Sprintf("Skipped %s (PID %d): protected by policy rule %d (warn)", name, pid, match.RuleIndex),
+				Error:   fmt.Errorf("protected by policy"),
+			}
+		}
+	}
+
+	p, err := process.NewProcess(pid)
+	if err != nil {
+		return Result{
+			Success: true, // already gone, which is the desired end state
+			Message: fmt.Sprintf("Process %d (%s) already terminated", pid, name),
+		}
+	}
+
+	_ = p.Terminate()
+
+	deadline := time.Now().Add(grace)
+	for time.Now().Before(deadline) {
+		if running, _ := p.IsRunning(); !running {
+			return Result{Success: true, Message: fmt.Sprintf("Successfully terminated process %d (%s)", pid, name)}
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if running, _ := p.IsRunning(); running {
+		if err := p.Kill(); err != nil {
+			return k.handleError(err, pid, name)
+		}
+	}
+
+	return Result{Success: true, Message: fmt.Sprintf("Successfully killed process %d (%s)", pid, name)}
+}
+
 // getProcessName retrieves the name of a process
 func (k *Killer) getProcessName(pid int32) (string, error) {
 	p, err := process.NewProcess(pid)
@@ -0,0 +1,93 @@
+// Package metrics exposes a live Prometheus scrape endpoint over the
+// current port snapshot, so PortExec can run as a lightweight
+// host-level exporter alongside its interactive role.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"portexec/internal/models"
+	"portexec/internal/ports"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultCacheTTL bounds how often a scrape triggers a fresh scan, so a
+// thundering herd of scrapers (or a short scrape interval) doesn't
+// repeatedly walk the process table.
+const defaultCacheTTL = 2 * time.Second
+
+// Handler serves a Prometheus text-exposition snapshot of the
+// currently listening ports.
+type Handler struct {
+	scanner  ports.PortSource
+	cacheTTL time.Duration
+
+	mu       sync.Mutex
+	cached   []models.PortEntry
+	cachedAt time.Time
+}
+
+// NewHandler creates a Handler backed by scanner, using the default
+// cache TTL.
+func NewHandler(scanner ports.PortSource) *Handler {
+	return &Handler{scanner: scanner, cacheTTL: defaultCacheTTL}
+}
+
+// ServeHTTP implements http.Handler, writing the current snapshot (or a
+// cached one, within cacheTTL) in Prometheus text-exposition format.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	entries, err := h.snapshot()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to scan ports: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	writeMetrics(w, entries)
+}
+
+// snapshot returns the cached entries if they're still fresh, or scans
+// again and refreshes the cache otherwise.
+func (h *Handler) snapshot() ([]models.PortEntry, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.cached != nil && time.Since(h.cachedAt) < h.cacheTTL {
+		return h.cached, nil
+	}
+
+	entries, err := h.scanner.GetListeningPorts()
+	if err != nil {
+		return nil, err
+	}
+
+	h.cached = entries
+	h.cachedAt = time.Now()
+	return entries, nil
+}
+
+// writeMetrics emits portexec_listening_ports and
+// portexec_process_uptime_seconds gauges, one series per entry.
+func writeMetrics(w io.Writer, entries []models.PortEntry) {
+	fmt.Fprintln(w, "# HELP portexec_listening_ports Whether a port was observed listening at scrape time (always 1).")
+	fmt.Fprintln(w, "# TYPE portexec_listening_ports gauge")
+	for _, e := range entries {
+		fmt.Fprintf(w, "portexec_listening_ports{proto=%q,port=%q,process=%q} 1\n",
+			e.Protocol, strconv.FormatUint(uint64(e.Port), 10), e.ProcessName)
+	}
+
+	fmt.Fprintln(w, "# HELP portexec_process_uptime_seconds Seconds since the process bound to a listening port started.")
+	fmt.Fprintln(w, "# TYPE portexec_process_uptime_seconds gauge")
+	seen := make(map[int32]bool)
+	for _, e := range entries {
+		if seen[e.PID] {
+			continue // one series per process, not per port it holds
+		}
+		seen[e.PID] = true
+		fmt.Fprintf(w, "portexec_process_uptime_seconds{pid=%q,name=%q} %d\n",
+			strconv.FormatInt(int64(e.PID), 10), e.ProcessName, int64(e.Uptime.Seconds()))
+	}
+}
@@ -0,0 +1,355 @@
+// Package policy implements a single auto-kill enforcement engine loaded
+// from a YAML config file, consulted both by killer.Killer (to protect
+// warn-listed processes from an unqualified Kill) and by the TUI/enforce
+// command (to auto-kill, alert on, or ignore matching entries). Every
+// kill/warn decision made through the enforce command is additionally
+// recorded to an audit log.
+//
+// This package used to be two separate engines (this one, plus
+// internal/rules) with separate config files and action vocabularies
+// run side by side against the same process list. They've been merged
+// into one engine/schema so there's a single place to reason about
+// which rule acts on a given process first.
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"portexec/internal/models"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+	"gopkg.in/yaml.v3"
+)
+
+// Action describes what an enforcement pass should do with a matching entry.
+type Action string
+
+const (
+	// ActionKill terminates the matching process.
+	ActionKill Action = "kill"
+	// ActionWarn only records the match; the process is left running and
+	// protected from an unqualified killer.Killer.Kill call.
+	ActionWarn Action = "warn"
+	// ActionAlert surfaces the match (TUI banner, webhook) but neither
+	// kills nor protects the process.
+	ActionAlert Action = "alert"
+	// ActionIgnore leaves the process alone (the enforce command still
+	// records the decision in its audit log); it exists so a rule can short-circuit
+	// matching for entries that would otherwise fall through to a later,
+	// broader rule.
+	ActionIgnore Action = "ignore"
+)
+
+// Duration wraps time.Duration so it can be unmarshaled from YAML
+// strings like "1h" or "90m" instead of raw nanoseconds.
+type Duration time.Duration
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	if s == "" {
+		*d = 0
+		return nil
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", s, err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Rule is a single predicate-action pair loaded from the policy config.
+type Rule struct {
+	Port             string   `yaml:"port,omitempty"`
+	PortRange        string   `yaml:"port_range,omitempty"`
+	ProcessNameRegex string   `yaml:"process_name_regex,omitempty"`
+	MinUptime        Duration `yaml:"min_uptime,omitempty"`
+	ExcludeUsers     []string `yaml:"exclude_users,omitempty"`
+	Action           Action   `yaml:"action"`
+	RequireConfirm   bool     `yaml:"require_confirm,omitempty"`
+	Webhook          string   `yaml:"webhook,omitempty"`
+
+	re               *regexp.Regexp
+	minPort, maxPort uint32
+}
+
+// compile validates and prepares a rule for matching.
+func (r *Rule) compile() error {
+	if r.ProcessNameRegex != "" {
+		re, err := regexp.Compile(r.ProcessNameRegex)
+		if err != nil {
+			return fmt.Errorf("invalid process_name_regex %q: %w", r.ProcessNameRegex, err)
+		}
+		r.re = re
+	}
+
+	portSpec := r.PortRange
+	if portSpec == "" {
+		portSpec = r.Port
+	}
+	if portSpec != "" {
+		min, max, err := parsePortRange(portSpec)
+		if err != nil {
+			return fmt.Errorf("invalid port/port_range %q: %w", portSpec, err)
+		}
+		r.minPort, r.maxPort = min, max
+	}
+
+	switch r.Action {
+	case ActionKill, ActionWarn, ActionAlert, ActionIgnore:
+	default:
+		return fmt.Errorf("invalid action %q (want kill, warn, alert, or ignore)", r.Action)
+	}
+
+	return nil
+}
+
+// Matches reports whether entry satisfies every predicate on the rule.
+// owner is the username the entry's process runs as, looked up lazily by
+// the caller since it isn't part of models.PortEntry.
+func (r *Rule) Matches(e models.PortEntry, owner string) bool {
+	if (r.minPort != 0 || r.maxPort != 0) && (e.Port < r.minPort || e.Port > r.maxPort) {
+		return false
+	}
+	if r.re != nil && !r.re.MatchString(e.ProcessName) {
+		return false
+	}
+	if time.Duration(r.MinUptime) > 0 && e.Uptime < time.Duration(r.MinUptime) {
+		return false
+	}
+	for _, excluded := range r.ExcludeUsers {
+		if strings.EqualFold(excluded, owner) {
+			return false
+		}
+	}
+	return true
+}
+
+// parsePortRange parses "3000" or "8000-8100" into an inclusive range.
+func parsePortRange(s string) (uint32, uint32, error) {
+	parts := strings.SplitN(s, "-", 2)
+
+	min, err := strconv.ParseUint(strings.TrimSpace(parts[0]), 10, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(parts) == 1 {
+		return uint32(min), uint32(min), nil
+	}
+
+	max, err := strconv.ParseUint(strings.TrimSpace(parts[1]), 10, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+	return uint32(min), uint32(max), nil
+}
+
+// config is the on-disk shape of the policy file.
+type config struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Engine holds a compiled, ordered policy rule list and per-rule hit counts.
+type Engine struct {
+	Rules     []Rule
+	hitCounts []int
+}
+
+// Load reads and compiles the policy config at path.
+func Load(path string) (*Engine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file %s: %w", path, err)
+	}
+
+	var cfg config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file %s: %w", path, err)
+	}
+
+	for i := range cfg.Rules {
+		if err := cfg.Rules[i].compile(); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+	}
+
+	return &Engine{Rules: cfg.Rules, hitCounts: make([]int, len(cfg.Rules))}, nil
+}
+
+// DefaultPath returns the default policy config location, ~/.portexec/policy.yaml.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".portexec", "policy.yaml"), nil
+}
+
+// Match pairs a port entry with the first rule in the ordered list that
+// it satisfied, and the owner username used to evaluate exclude_users.
+type Match struct {
+	Entry     models.PortEntry
+	Owner     string
+	Rule      *Rule
+	RuleIndex int
+}
+
+// Evaluate matches each entry against the ordered rule list, stopping at
+// the first rule that matches, and bumps that rule's hit count.
+func (e *Engine) Evaluate(entries []models.PortEntry) []Match {
+	var matches []Match
+
+	for _, entry := range entries {
+		owner := ownerOf(entry.PID)
+		for i := range e.Rules {
+			rule := &e.Rules[i]
+			if !rule.Matches(entry, owner) {
+				continue
+			}
+			e.hitCounts[i]++
+			matches = append(matches, Match{Entry: entry, Owner: owner, Rule: rule, RuleIndex: i})
+			break
+		}
+	}
+
+	return matches
+}
+
+// HitCount returns how many times the rule at i has matched.
+func (e *Engine) HitCount(i int) int {
+	if i < 0 || i >= len(e.hitCounts) {
+		return 0
+	}
+	return e.hitCounts[i]
+}
+
+// Notify posts entry as a JSON body to rule's webhook, if configured.
+func Notify(rule *Rule, entry models.PortEntry) error {
+	if rule.Webhook == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	resp, err := http.Post(rule.Webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// MatchOne evaluates a single entry against the ordered rule list,
+// returning the first rule it satisfies, if any. This is the entry
+// point killer.Killer uses before acting on a single PID.
+func (e *Engine) MatchOne(entry models.PortEntry) (Match, bool) {
+	owner := ownerOf(entry.PID)
+	for i := range e.Rules {
+		rule := &e.Rules[i]
+		if rule.Matches(entry, owner) {
+			return Match{Entry: entry, Owner: owner, Rule: rule, RuleIndex: i}, true
+		}
+	}
+	return Match{}, false
+}
+
+// ownerOf looks up the username a process runs as, returning "" if it
+// can't be determined (e.g. the process has already exited).
+func ownerOf(pid int32) string {
+	p, err := process.NewProcess(pid)
+	if err != nil {
+		return ""
+	}
+	username, err := p.Username()
+	if err != nil {
+		return ""
+	}
+	return username
+}
+
+// Decision is a single structured audit log entry recording what an
+// enforcement pass did (or would have done, in dry-run mode) about a
+// policy match.
+type Decision struct {
+	Time      time.Time        `json:"time"`
+	Action    Action           `json:"action"`
+	RuleIndex int              `json:"rule_index"`
+	DryRun    bool             `json:"dry_run"`
+	Outcome   string           `json:"outcome"`
+	Entry     models.PortEntry `json:"entry"`
+}
+
+// AuditLogger appends newline-delimited JSON Decisions to a log file,
+// so every enforcement action PortExec takes on a user's behalf has a
+// durable record.
+type AuditLogger struct {
+	w io.Writer
+	f *os.File
+}
+
+// NewAuditLogger opens (creating if necessary) the audit log at path
+// for appending.
+func NewAuditLogger(path string) (*AuditLogger, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log %s: %w", path, err)
+	}
+
+	return &AuditLogger{w: f, f: f}, nil
+}
+
+// Log appends d to the audit log as a single JSON line, stamping it
+// with the current time.
+func (a *AuditLogger) Log(d Decision) error {
+	d.Time = time.Now()
+	data, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+	data = append(data, '\n')
+	_, err = a.w.Write(data)
+	return err
+}
+
+// Close releases the underlying log file.
+func (a *AuditLogger) Close() error {
+	if a.f == nil {
+		return nil
+	}
+	return a.f.Close()
+}
+
+// DefaultAuditPath returns the default audit log location, ~/.portexec/audit.log.
+func DefaultAuditPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".portexec", "audit.log"), nil
+}
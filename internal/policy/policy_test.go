@@ -0,0 +1,113 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"portexec/internal/models"
+)
+
+func writePolicyFile(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("failed to write test policy file: %v", err)
+	}
+	return path
+}
+
+func TestLoadAndMatchOne(t *testing.T) {
+	path := writePolicyFile(t, `
+rules:
+  - port_range: 8000-8100
+    action: warn
+  - process_name_regex: "^node$"
+    action: kill
+`)
+
+	engine, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(engine.Rules) != 2 {
+		t.Fatalf("got %d rules, want 2", len(engine.Rules))
+	}
+
+	match, ok := engine.MatchOne(models.PortEntry{Port: 8080, ProcessName: "nginx"})
+	if !ok || match.Rule.Action != ActionWarn {
+		t.Fatalf("MatchOne(port 8080) = %+v, %v, want the warn rule to match", match, ok)
+	}
+
+	match, ok = engine.MatchOne(models.PortEntry{Port: 3000, ProcessName: "node"})
+	if !ok || match.Rule.Action != ActionKill {
+		t.Fatalf("MatchOne(node) = %+v, %v, want the kill rule to match", match, ok)
+	}
+
+	if _, ok := engine.MatchOne(models.PortEntry{Port: 3000, ProcessName: "python"}); ok {
+		t.Fatal("MatchOne matched an entry that satisfies no rule")
+	}
+}
+
+func TestRuleMatchesMinUptimeAndExcludeUsers(t *testing.T) {
+	rule := &Rule{
+		MinUptime:    Duration(time.Minute),
+		ExcludeUsers: []string{"root"},
+		Action:       ActionKill,
+	}
+	if err := rule.compile(); err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	young := models.PortEntry{Uptime: 10 * time.Second}
+	if rule.Matches(young, "alice") {
+		t.Error("Matches(young process) = true, want false (below min_uptime)")
+	}
+
+	old := models.PortEntry{Uptime: time.Hour}
+	if !rule.Matches(old, "alice") {
+		t.Error("Matches(old process, non-excluded user) = false, want true")
+	}
+	if rule.Matches(old, "root") {
+		t.Error("Matches(old process, excluded user) = true, want false")
+	}
+	if rule.Matches(old, "Root") {
+		t.Error("Matches should compare exclude_users case-insensitively")
+	}
+}
+
+func TestEngineEvaluateTracksHitCounts(t *testing.T) {
+	path := writePolicyFile(t, `
+rules:
+  - port: 8080
+    action: warn
+`)
+	engine, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	entries := []models.PortEntry{
+		{Port: 8080, ProcessName: "nginx"},
+		{Port: 8080, ProcessName: "nginx"},
+		{Port: 9090, ProcessName: "other"},
+	}
+	matches := engine.Evaluate(entries)
+	if len(matches) != 2 {
+		t.Fatalf("got %d matches, want 2", len(matches))
+	}
+	if got := engine.HitCount(0); got != 2 {
+		t.Errorf("HitCount(0) = %d, want 2", got)
+	}
+	if got := engine.HitCount(99); got != 0 {
+		t.Errorf("HitCount(out of range) = %d, want 0", got)
+	}
+}
+
+func TestCompileRejectsInvalidAction(t *testing.T) {
+	rule := &Rule{Action: "nuke"}
+	if err := rule.compile(); err == nil {
+		t.Fatal("compile with an invalid action returned no error")
+	}
+}
@@ -0,0 +1,57 @@
+package ports
+
+// RawConnection is a single TCP/UDP socket observed by a Backend,
+// before process metadata (name, parent PID, uptime, exe path) has
+// been attached.
+type RawConnection struct {
+	Protocol     string
+	LocalAddress string
+	Port         uint32
+	PID          int32
+	State        string
+}
+
+// Backend enumerates raw sockets on the current host. Scanner attaches
+// process metadata on top of whatever a Backend returns, so a Backend
+// only has to answer "what sockets exist and which PID owns each one"
+// as cheaply as the platform allows.
+type Backend interface {
+	// Name identifies the backend for diagnostics (e.g. "procnet", "lsof", "gopsutil").
+	Name() string
+	// Connections returns every TCP/UDP socket currently open, independent of state.
+	Connections() ([]RawConnection, error)
+}
+
+// normalizeState maps a backend's raw state string onto PortExec's
+// canonical state names, so the TUI/CLI render identically regardless
+// of which backend produced the data.
+func normalizeState(raw string) string {
+	switch raw {
+	case "LISTEN", "LISTENING", "0A":
+		return "LISTENING"
+	case "ESTABLISHED", "01":
+		return "ESTABLISHED"
+	case "TIME_WAIT", "06":
+		return "TIME_WAIT"
+	case "CLOSE_WAIT", "08":
+		return "CLOSE_WAIT"
+	case "SYN_SENT", "02":
+		return "SYN_SENT"
+	case "SYN_RECV", "SYN_RECEIVED", "03":
+		return "SYN_RECV"
+	case "FIN_WAIT1", "FIN_WAIT_1", "04":
+		return "FIN_WAIT1"
+	case "FIN_WAIT2", "FIN_WAIT_2", "05":
+		return "FIN_WAIT2"
+	case "LAST_ACK", "09":
+		return "LAST_ACK"
+	case "CLOSING", "0B":
+		return "CLOSING"
+	case "CLOSE", "CLOSED", "07":
+		return "CLOSED"
+	case "":
+		return "BOUND" // UDP sockets have no connection state
+	default:
+		return raw
+	}
+}
@@ -0,0 +1,111 @@
+//go:build darwin
+
+package ports
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// lsofBackend enumerates sockets by shelling out to `lsof -iTCP -iUDP
+// -n -P -F`, the conventional way to list socket ownership on macOS
+// without CGo bindings into the BSD socket tables.
+type lsofBackend struct{}
+
+func newLsofBackend() *lsofBackend {
+	return &lsofBackend{}
+}
+
+func (b *lsofBackend) Name() string { return "lsof" }
+
+func (b *lsofBackend) Connections() ([]RawConnection, error) {
+	out, err := exec.Command("lsof", "-iTCP", "-iUDP", "-n", "-P", "-F", "pcnPLT").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run lsof: %w", err)
+	}
+
+	return parseLsofOutput(string(out)), nil
+}
+
+// parseLsofOutput parses lsof's field-oriented `-F` output, where each
+// process starts with a "p<pid>" line, "P<proto>" carries the
+// protocol, "n<name>" carries the socket name for each following file
+// descriptor, and an optional "T" line carries the TCP state.
+func parseLsofOutput(output string) []RawConnection {
+	var (
+		entries []RawConnection
+		pid     int64
+		proto   string
+	)
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		switch line[0] {
+		case 'p':
+			pid, _ = strconv.ParseInt(line[1:], 10, 32)
+		case 'P':
+			proto = strings.ToUpper(line[1:])
+		case 'n':
+			addr, port, ok := parseLsofName(line[1:])
+			if !ok {
+				continue
+			}
+			entries = append(entries, RawConnection{
+				Protocol:     proto,
+				LocalAddress: addr,
+				Port:         port,
+				PID:          int32(pid),
+			})
+		case 'T':
+			if len(entries) == 0 {
+				continue
+			}
+			if st, ok := strings.CutPrefix(line[1:], "ST="); ok {
+				entries[len(entries)-1].State = normalizeState(st)
+			}
+		}
+	}
+
+	for i := range entries {
+		if entries[i].State == "" {
+			entries[i].State = normalizeState("") // UDP sockets have no TCP state line
+		}
+	}
+
+	return entries
+}
+
+// parseLsofName splits an lsof socket name field ("127.0.0.1:8080" or
+// "127.0.0.1:8080->127.0.0.1:54321") into the local address:port,
+// discarding the remote side.
+func parseLsofName(name string) (string, uint32, bool) {
+	local := name
+	if idx := strings.Index(name, "->"); idx >= 0 {
+		local = name[:idx]
+	}
+
+	idx := strings.LastIndex(local, ":")
+	if idx < 0 {
+		return "", 0, false
+	}
+
+	portStr := local[idx+1:]
+	if portStr == "*" {
+		return "", 0, false
+	}
+
+	port, err := strconv.ParseUint(portStr, 10, 32)
+	if err != nil {
+		return "", 0, false
+	}
+
+	return local, uint32(port), true
+}
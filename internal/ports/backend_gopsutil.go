@@ -0,0 +1,63 @@
+package ports
+
+import (
+	"fmt"
+	"net"
+
+	gopsutilnet "github.com/shirou/gopsutil/v3/net"
+)
+
+// gopsutilBackend enumerates sockets via gopsutil/v3/net, which on
+// Linux walks every /proc/*/fd/* symlink to map inodes to PIDs. It's
+// the slowest backend but the only one that works unmodified on every
+// OS gopsutil supports, so it remains the default on unrecognized
+// platforms. Scanner does not fall back to it when another backend
+// fails.
+type gopsutilBackend struct{}
+
+func newGopsutilBackend() *gopsutilBackend {
+	return &gopsutilBackend{}
+}
+
+func (b *gopsutilBackend) Name() string { return "gopsutil" }
+
+func (b *gopsutilBackend) Connections() ([]RawConnection, error) {
+	conns, err := gopsutilnet.Connections("all")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network connections: %w", err)
+	}
+
+	out := make([]RawConnection, 0, len(conns))
+	for _, conn := range conns {
+		if conn.Pid == 0 {
+			continue
+		}
+		// Only TCP (1) and UDP (2) sockets are relevant to PortExec.
+		if conn.Type != 1 && conn.Type != 2 {
+			continue
+		}
+
+		proto := "UNKNOWN"
+		switch conn.Type {
+		case 1:
+			proto = "TCP"
+		case 2:
+			proto = "UDP"
+		}
+
+		localAddr := conn.Laddr.IP
+		if localAddr == "" {
+			localAddr = "0.0.0.0"
+		}
+
+		out = append(out, RawConnection{
+			Protocol:     proto,
+			LocalAddress: net.JoinHostPort(localAddr, fmt.Sprintf("%d", conn.Laddr.Port)),
+			Port:         uint32(conn.Laddr.Port),
+			PID:          conn.Pid,
+			State:        normalizeState(conn.Status),
+		})
+	}
+
+	return out, nil
+}
@@ -0,0 +1,8 @@
+//go:build darwin
+
+package ports
+
+// defaultBackend returns the fastest Backend available on this OS.
+func defaultBackend() Backend {
+	return newLsofBackend()
+}
@@ -0,0 +1,8 @@
+//go:build linux
+
+package ports
+
+// defaultBackend returns the fastest Backend available on this OS.
+func defaultBackend() Backend {
+	return newProcNetBackend()
+}
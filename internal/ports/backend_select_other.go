@@ -0,0 +1,9 @@
+//go:build !linux && !darwin && !windows
+
+package ports
+
+// defaultBackend falls back to gopsutil on platforms without a
+// dedicated fast-path backend.
+func defaultBackend() Backend {
+	return newGopsutilBackend()
+}
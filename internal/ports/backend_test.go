@@ -0,0 +1,29 @@
+package ports
+
+import "testing"
+
+func TestNormalizeState(t *testing.T) {
+	cases := []struct {
+		raw  string
+		want string
+	}{
+		{"LISTEN", "LISTENING"},
+		{"LISTENING", "LISTENING"},
+		{"0A", "LISTENING"},
+		{"ESTABLISHED", "ESTABLISHED"},
+		{"01", "ESTABLISHED"},
+		{"SYN_RECEIVED", "SYN_RECV"},
+		{"03", "SYN_RECV"},
+		{"FIN_WAIT_1", "FIN_WAIT1"},
+		{"CLOSE", "CLOSED"},
+		{"CLOSED", "CLOSED"},
+		{"", "BOUND"},
+		{"SOMETHING_UNKNOWN", "SOMETHING_UNKNOWN"},
+	}
+
+	for _, c := range cases {
+		if got := normalizeState(c.raw); got != c.want {
+			t.Errorf("normalizeState(%q) = %q, want %q", c.raw, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+//go:build windows
+
+package ports
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	iphlpapi           = syscall.NewLazyDLL("iphlpapi.dll")
+	procGetExtTCPTable = iphlpapi.NewProc("GetExtendedTcpTable")
+	procGetExtUDPTable = iphlpapi.NewProc("GetExtendedUdpTable")
+)
+
+const (
+	afINET              = 2
+	tcpTableOwnerPIDAll = 5
+	udpTableOwnerPID    = 1
+)
+
+// mibTCPRowOwnerPID mirrors the Win32 MIB_TCPROW_OWNER_PID struct.
+type mibTCPRowOwnerPID struct {
+	State      uint32
+	LocalAddr  uint32
+	LocalPort  [4]byte
+	RemoteAddr uint32
+	RemotePort [4]byte
+	OwningPID  uint32
+}
+
+// mibUDPRowOwnerPID mirrors the Win32 MIB_UDPROW_OWNER_PID struct.
+type mibUDPRowOwnerPID struct {
+	LocalAddr uint32
+	LocalPort [4]byte
+	OwningPID uint32
+}
+
+// iphlpapiBackend enumerates sockets via the iphlpapi
+// GetExtendedTcpTable/GetExtendedUdpTable APIs, which return PID
+// ownership directly instead of gopsutil's WMI-backed lookups.
+type iphlpapiBackend struct{}
+
+func newIPHlpAPIBackend() *iphlpapiBackend {
+	return &iphlpapiBackend{}
+}
+
+func (b *iphlpapiBackend) Name() string { return "iphlpapi" }
+
+func (b *iphlpapiBackend) Connections() ([]RawConnection, error) {
+	tcp, err := b.tcpConnections()
+	if err != nil {
+		return nil, err
+	}
+	udp, err := b.udpConnections()
+	if err != nil {
+		return nil, err
+	}
+	return append(tcp, udp...), nil
+}
+
+func (b *iphlpapiBackend) tcpConnections() ([]RawConnection, error) {
+	buf, err := fetchExtendedTable(procGetExtTCPTable, tcpTableOwnerPIDAll)
+	if err != nil {
+		return nil, fmt.Errorf("GetExtendedTcpTable: %w", err)
+	}
+
+	count := *(*uint32)(unsafe.Pointer(&buf[0]))
+	rowSize := unsafe.Sizeof(mibTCPRowOwnerPID{})
+	out := make([]RawConnection, 0, count)
+
+	for i := uint32(0); i < count; i++ {
+		row := (*mibTCPRowOwnerPID)(unsafe.Pointer(&buf[4+uintptr(i)*rowSize]))
+		port := portOf(row.LocalPort)
+		out = append(out, RawConnection{
+			Protocol:     "TCP",
+			LocalAddress: net.JoinHostPort(ipv4String(row.LocalAddr), fmt.Sprintf("%d", port)),
+			Port:         uint32(port),
+			PID:          int32(row.OwningPID),
+			State:        normalizeState(tcpStateName(row.State)),
+		})
+	}
+
+	return out, nil
+}
+
+func (b *iphlpapiBackend) udpConnections() ([]RawConnection, error) {
+	buf, err := fetchExtendedTable(procGetExtUDPTable, udpTableOwnerPID)
+	if err != nil {
+		return nil, fmt.Errorf("GetExtendedUdpTable: %w", err)
+	}
+
+	count := *(*uint32)(unsafe.Pointer(&buf[0]))
+	rowSize := unsafe.Sizeof(mibUDPRowOwnerPID{})
+	out := make([]RawConnection, 0, count)
+
+	for i := uint32(0); i < count; i++ {
+		row := (*mibUDPRowOwnerPID)(unsafe.Pointer(&buf[4+uintptr(i)*rowSize]))
+		port := portOf(row.LocalPort)
+		out = append(out, RawConnection{
+			Protocol:     "UDP",
+			LocalAddress: net.JoinHostPort(ipv4String(row.LocalAddr), fmt.Sprintf("%d", port)),
+			Port:         uint32(port),
+			PID:          int32(row.OwningPID),
+			State:        normalizeState(""),
+		})
+	}
+
+	return out, nil
+}
+
+// fetchExtendedTable calls one of the GetExtended*Table procs twice:
+// once to learn the required buffer size, then again to fill it, which
+// is the documented usage pattern for these APIs.
+func fetchExtendedTable(proc *syscall.LazyProc, class uint32) ([]byte, error) {
+	var size uint32
+	proc.Call(0, uintptr(unsafe.Pointer(&size)), 1, afINET, uintptr(class), 0)
+
+	buf := make([]byte, size)
+	ret, _, _ := proc.Call(
+		uintptr(unsafe.Pointer(&buf[0])),
+		uintptr(unsafe.Pointer(&size)),
+		1, afINET, uintptr(class), 0,
+	)
+	if ret != 0 {
+		return nil, fmt.Errorf("syscall returned error code %d", ret)
+	}
+
+	return buf, nil
+}
+
+func portOf(raw [4]byte) uint16 {
+	return binary.BigEndian.Uint16(raw[:2])
+}
+
+func ipv4String(addr uint32) string {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, addr)
+	return net.IP(b).String()
+}
+
+func tcpStateName(state uint32) string {
+	switch state {
+	case 1:
+		return "CLOSED"
+	case 2:
+		return "LISTEN"
+	case 3:
+		return "SYN_SENT"
+	case 4:
+		return "SYN_RECV"
+	case 5:
+		return "ESTABLISHED"
+	case 6:
+		return "FIN_WAIT1"
+	case 7:
+		return "FIN_WAIT2"
+	case 8:
+		return "CLOSE_WAIT"
+	case 9:
+		return "CLOSING"
+	case 10:
+		return "LAST_ACK"
+	case 11:
+		return "TIME_WAIT"
+	case 12:
+		return "DELETE_TCB"
+	default:
+		return "UNKNOWN"
+	}
+}
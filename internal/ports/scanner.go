@@ -2,20 +2,38 @@ package ports
 
 import (
 	"fmt"
-	"net"
 	"portexec/internal/models"
 	"portexec/internal/processes"
+	"strings"
 	"time"
+)
 
-	gopsutilnet "github.com/shirou/gopsutil/v3/net"
-) // Scanner handles network port scanning and connection enumeration
+// Scanner handles network port scanning and connection enumeration
 type Scanner struct {
+	backend       Backend
 	processGetter *processes.Getter
 }
 
-// NewScanner creates a new port scanner
+// PortSource abstracts port/connection enumeration so callers (the CLI,
+// the TUI) can be pointed at a local Scanner or a remote RPC client
+// interchangeably.
+type PortSource interface {
+	GetListeningPorts() ([]models.PortEntry, error)
+	GetConnections(states []string) ([]models.PortEntry, error)
+}
+
+// NewScanner creates a port scanner using the fastest Backend available
+// for the current OS (see defaultBackend).
 func NewScanner() *Scanner {
+	return NewScannerWithBackend(defaultBackend())
+}
+
+// NewScannerWithBackend creates a port scanner using an explicit
+// Backend, letting callers opt into (or test against) a specific
+// enumeration strategy instead of the OS default.
+func NewScannerWithBackend(backend Backend) *Scanner {
 	return &Scanner{
+		backend:       backend,
 		processGetter: processes.NewGetter(),
 	}
 }
@@ -27,8 +45,15 @@ func (s *Scanner) GetListeningPorts() ([]models.PortEntry, error) {
 
 // GetConnections returns connections filtered by states
 func (s *Scanner) GetConnections(states []string) ([]models.PortEntry, error) {
-	// Get all network connections
-	conns, err := gopsutilnet.Connections("all")
+	// Requested states go through normalizeState like the backend's
+	// states do, so "listen" and "LISTENING" select the same sockets.
+	wanted := make([]string, len(states))
+	for i, st := range states {
+		wanted[i] = normalizeState(strings.ToUpper(st))
+	}
+
+	// Get all raw sockets from the platform backend
+	conns, err := s.backend.Connections()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get network connections: %w", err)
 	}
@@ -40,46 +65,31 @@ func (s *Scanner) GetConnections(states []string) ([]models.PortEntry, error) {
 
 	for _, conn := range conns {
 		// Skip connections with no PID
-		if conn.Pid == 0 {
-			continue
-		}
-
-		// Only include TCP and UDP (type 1 = TCP, type 2 = UDP)
-		if conn.Type != 1 && conn.Type != 2 {
+		if conn.PID == 0 {
 			continue
 		}
 
-		// Convert state
-		state := s.formatState(conn.Status)
-
 		// Filter by state if specified
 		if len(states) > 0 {
-			if !containsState(states, state) {
+			if !containsState(wanted, conn.State) {
 				continue
 			}
 		}
 
-		// Get local address and port
-		localAddr := conn.Laddr.IP
-		if localAddr == "" {
-			localAddr = "0.0.0.0"
-		}
-		localPort := conn.Laddr.Port
-
 		// Get process info (cached)
-		procInfo, err := s.getProcessInfo(conn.Pid, pidCache)
+		procInfo, err := s.getProcessInfo(conn.PID, pidCache)
 		if err != nil {
 			// Skip if we can't get process info
 			continue
 		}
 
 		entry := models.PortEntry{
-			Protocol:     s.protocolString(conn.Type),
-			LocalAddress: net.JoinHostPort(localAddr, fmt.Sprintf("%d", localPort)),
-			Port:         uint32(localPort),
-			PID:          conn.Pid,
+			Protocol:     conn.Protocol,
+			LocalAddress: conn.LocalAddress,
+			Port:         conn.Port,
+			PID:          conn.PID,
 			ProcessName:  procInfo.Name,
-			State:        state,
+			State:        conn.State,
 			ParentPID:    procInfo.ParentPID,
 			Uptime:       procInfo.Uptime,
 			ExePath:      procInfo.ExePath,
@@ -107,52 +117,6 @@ func (s *Scanner) getProcessInfo(pid int32, cache map[int32]*models.ProcessInfo)
 	return info, nil
 }
 
-// formatState converts connection status to display format
-func (s *Scanner) formatState(status string) string {
-	switch status {
-	case "LISTEN":
-		return "LISTENING"
-	case "ESTABLISHED":
-		return "ESTABLISHED"
-	case "TIME_WAIT":
-		return "TIME_WAIT"
-	case "CLOSE_WAIT":
-		return "CLOSE_WAIT"
-	case "SYN_SENT":
-		return "SYN_SENT"
-	case "SYN_RECV":
-		return "SYN_RECV"
-	case "FIN_WAIT1":
-		return "FIN_WAIT1"
-	case "FIN_WAIT2":
-		return "FIN_WAIT2"
-	case "LAST_ACK":
-		return "LAST_ACK"
-	case "CLOSING":
-		return "CLOSING"
-	case "CLOSED":
-		return "CLOSED"
-	case "IDLE":
-		return "IDLE"
-	case "BOUND":
-		return "BOUND"
-	default:
-		return status
-	}
-}
-
-// protocolString returns the protocol name
-func (s *Scanner) protocolString(t gopsutilnet.ConnectionType) string {
-	switch t {
-	case gopsutilnet.TCP:
-		return "TCP"
-	case gopsutilnet.UDP:
-		return "UDP"
-	default:
-		return "UNKNOWN"
-	}
-}
-
 // containsState checks if state is in the list
 func containsState(states []string, state string) bool {
 	for _, s := range states {
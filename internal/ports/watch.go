@@ -0,0 +1,98 @@
+package ports
+
+import (
+	"context"
+	"fmt"
+	"portexec/internal/models"
+	"time"
+)
+
+// PortEventType categorizes a PortEvent emitted by Scanner.Watch.
+type PortEventType int
+
+const (
+	PortEventAdded PortEventType = iota
+	PortEventRemoved
+	PortEventStateChanged
+)
+
+// String implements fmt.Stringer so events print as e.g. "ADDED"
+// instead of a bare integer.
+func (t PortEventType) String() string {
+	switch t {
+	case PortEventAdded:
+		return "ADDED"
+	case PortEventRemoved:
+		return "REMOVED"
+	case PortEventStateChanged:
+		return "STATE_CHANGED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// PortEvent is a single change observed between two Scanner snapshots.
+type PortEvent struct {
+	Type  PortEventType
+	Entry models.PortEntry
+}
+
+// Watch periodically re-scans every interval and diffs against the
+// previous snapshot, sending one PortEvent per added/removed/
+// state-changed connection, keyed by (protocol, local address, PID).
+// The returned channel is closed once ctx is canceled.
+//
+// This polls GetConnections on a timer; a platform with netlink
+// SOCK_DIAG subscriptions (Linux) could push events immediately
+// instead, but that fast path isn't implemented here.
+func (s *Scanner) Watch(ctx context.Context, interval time.Duration) (<-chan PortEvent, error) {
+	events := make(chan PortEvent)
+
+	go func() {
+		defer close(events)
+
+		prev := make(map[string]models.PortEntry)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if entries, err := s.GetConnections(nil); err == nil {
+				cur := make(map[string]models.PortEntry, len(entries))
+				for _, e := range entries {
+					cur[watchKey(e)] = e
+				}
+
+				for key, e := range cur {
+					old, existed := prev[key]
+					switch {
+					case !existed:
+						events <- PortEvent{Type: PortEventAdded, Entry: e}
+					case old.State != e.State:
+						events <- PortEvent{Type: PortEventStateChanged, Entry: e}
+					}
+				}
+				for key, e := range prev {
+					if _, stillPresent := cur[key]; !stillPresent {
+						events <- PortEvent{Type: PortEventRemoved, Entry: e}
+					}
+				}
+
+				prev = cur
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// watchKey identifies a connection across snapshots for diffing
+// purposes.
+func watchKey(e models.PortEntry) string {
+	return fmt.Sprintf("%s:%s:%d", e.Protocol, e.LocalAddress, e.PID)
+}
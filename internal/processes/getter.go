@@ -132,3 +132,31 @@ func (g *Getter) GetAllPIDs() ([]int32, error) {
 	}
 	return pids, nil
 }
+
+// GetChildren returns the PIDs of pid's direct children, found by
+// scanning every running process's parent PID. This is more expensive
+// than a single syscall but works identically across every OS
+// gopsutil supports, unlike a platform-specific children API.
+func (g *Getter) GetChildren(pid int32) ([]int32, error) {
+	pids, err := g.GetAllPIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	var children []int32
+	for _, candidate := range pids {
+		p, err := process.NewProcess(candidate)
+		if err != nil {
+			continue // process exited mid-scan
+		}
+		ppid, err := p.Ppid()
+		if err != nil {
+			continue
+		}
+		if ppid == pid {
+			children = append(children, candidate)
+		}
+	}
+
+	return children, nil
+}
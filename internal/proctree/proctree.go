@@ -0,0 +1,119 @@
+// Package proctree builds a process forest from a flat list of port
+// entries, resolving each entry's ancestor chain so the TUI can render
+// parent-child relationships (e.g. a dev server running under a shell
+// under a terminal emulator) instead of a flat table.
+package proctree
+
+import (
+	"portexec/internal/models"
+	"portexec/internal/processes"
+	"sort"
+)
+
+// Node is a single process in the tree. Entry is nil for ancestor
+// processes that hold no port themselves and were pulled in only as
+// part of a port-holding entry's ancestor chain (e.g. an intermediate
+// shell).
+type Node struct {
+	PID      int32
+	Name     string
+	Entry    *models.PortEntry
+	Children []*Node
+}
+
+// Builder constructs process forests from port entries, caching
+// ProcessInfo lookups across calls so repeated ancestors only hit the
+// OS once.
+type Builder struct {
+	getter *processes.Getter
+	cache  map[int32]*models.ProcessInfo
+}
+
+// NewBuilder creates a tree builder backed by getter.
+func NewBuilder(getter *processes.Getter) *Builder {
+	return &Builder{
+		getter: getter,
+		cache:  make(map[int32]*models.ProcessInfo),
+	}
+}
+
+// Build returns the root nodes of the forest formed by entries and the
+// ancestor chain of each entry's PID.
+func (b *Builder) Build(entries []models.PortEntry) []*Node {
+	nodes := make(map[int32]*Node, len(entries))
+	parentOf := make(map[int32]int32, len(entries))
+
+	for i := range entries {
+		e := &entries[i]
+		nodes[e.PID] = &Node{PID: e.PID, Name: e.ProcessName, Entry: e}
+		parentOf[e.PID] = e.ParentPID
+	}
+
+	// Walk ancestor chains, adding placeholder nodes for processes that
+	// don't themselves hold a port.
+	for pid, parentPID := range parentOf {
+		for parentPID != 0 && parentPID != pid {
+			if _, exists := nodes[parentPID]; exists {
+				break
+			}
+			info, err := b.processInfo(parentPID)
+			if err != nil {
+				break // ancestor no longer resolvable (exited, permissions)
+			}
+			nodes[parentPID] = &Node{PID: parentPID, Name: info.Name}
+			parentOf[parentPID] = info.ParentPID
+			pid, parentPID = parentPID, info.ParentPID
+		}
+	}
+
+	// Attach each node to its parent.
+	attached := make(map[int32]bool, len(nodes))
+	for pid, n := range nodes {
+		parentPID, ok := parentOf[pid]
+		if !ok || parentPID == 0 || parentPID == pid {
+			continue
+		}
+		parent, ok := nodes[parentPID]
+		if !ok {
+			continue
+		}
+		parent.Children = append(parent.Children, n)
+		attached[pid] = true
+	}
+
+	var roots []*Node
+	for pid, n := range nodes {
+		if !attached[pid] {
+			roots = append(roots, n)
+		}
+	}
+
+	// Both roots and each node's Children were built by iterating Go
+	// maps, which have no guaranteed order; sort by PID so the tree
+	// view is stable across refreshes instead of reshuffling rows every
+	// time Build runs on unchanged input.
+	sortByPID(roots)
+	for _, n := range nodes {
+		sortByPID(n.Children)
+	}
+
+	return roots
+}
+
+// sortByPID sorts nodes in place by PID.
+func sortByPID(nodes []*Node) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].PID < nodes[j].PID })
+}
+
+// processInfo returns cached process info or fetches and caches it.
+func (b *Builder) processInfo(pid int32) (*models.ProcessInfo, error) {
+	if info, ok := b.cache[pid]; ok {
+		return info, nil
+	}
+	info, err := b.getter.GetProcessInfo(pid)
+	if err != nil {
+		return nil, err
+	}
+	b.cache[pid] = info
+	return info, nil
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package rpc
+
+// DefaultSocketAddr is the Unix domain socket ServeLocal listens on
+// and DialLocal dials by default, mirroring containerd's
+// /run/containerd/containerd.sock convention.
+const DefaultSocketAddr = "/var/run/portexec.sock"
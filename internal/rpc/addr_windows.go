@@ -0,0 +1,7 @@
+//go:build windows
+
+package rpc
+
+// DefaultSocketAddr is the named pipe ServeLocal listens on and
+// DialLocal dials by default.
+const DefaultSocketAddr = `\\.\pipe\portexec`
@@ -0,0 +1,246 @@
+package rpc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"portexec/internal/killer"
+	"portexec/internal/models"
+	"strings"
+	"time"
+)
+
+// TrustPrompt is asked whether to trust a never-before-seen server
+// certificate, mirroring the SSH "authenticity of host can't be
+// established" prompt. It returns true to pin and proceed, false to
+// abort the connection.
+type TrustPrompt func(host, fingerprint string) bool
+
+// Client is a PortExec wire-protocol client, letting the TUI/CLI
+// operate against a remote or local-daemon PortSource/Terminator as if
+// it were the local Scanner/Killer. Every call dials a fresh
+// connection (the protocol is one request/response pair per
+// connection, or a long-lived stream for Watch), using whichever
+// dial func DialTLS or DialLocal configured.
+type Client struct {
+	dial func() (net.Conn, error)
+}
+
+// DialTLS connects to a remote PortExec server at addr (host:port)
+// over mutual TLS. If the server's certificate fingerprint isn't
+// already pinned in known_hosts, prompt is called to decide whether to
+// trust and pin it (TOFU), matching how SSH clients handle unknown
+// server certs.
+func DialTLS(addr string, known *KnownHosts, prompt TrustPrompt) (*Client, error) {
+	clientCert, err := ephemeralClientCert()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate client certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates:       []tls.Certificate{clientCert},
+		InsecureSkipVerify: true, // we verify the fingerprint ourselves, below
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return fmt.Errorf("server presented no certificate")
+			}
+			cert, err := x509.ParseCertificate(rawCerts[0])
+			if err != nil {
+				return fmt.Errorf("failed to parse server certificate: %w", err)
+			}
+
+			fp := Fingerprint(cert)
+			if pinned, ok := known.Lookup(addr); ok {
+				if pinned != fp {
+					return fmt.Errorf("certificate fingerprint mismatch for %s: expected %s, got %s (possible MITM)", addr, pinned, fp)
+				}
+				return nil
+			}
+
+			if prompt == nil || !prompt(addr, fp) {
+				return fmt.Errorf("certificate for %s not trusted", addr)
+			}
+			return known.Pin(addr, cert)
+		},
+	}
+
+	dial := func() (net.Conn, error) {
+		return tls.Dial("tcp", addr, tlsConfig)
+	}
+
+	conn, err := dial()
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial %s: %w", addr, err)
+	}
+	conn.Close()
+
+	return &Client{dial: dial}, nil
+}
+
+// ephemeralClientCert generates a throwaway, self-signed certificate
+// and key pair for the client side of the mTLS handshake. The server
+// only requires that a client present *some* certificate
+// (tls.RequireAnyClientCert in Serve); trust flows the other
+// direction, via the TOFU fingerprint pinning on the server's
+// certificate above, so the client identity itself doesn't need to be
+// verifiable or persisted across connections.
+func ephemeralClientCert() (tls.Certificate, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to generate client key: %w", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "portexec-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(10 * 365 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to create client certificate: %w", err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
+
+// DialLocal connects to a daemon's Unix domain socket (named pipe on
+// Windows) listening on addr. addr may be a bare socket/pipe path or a
+// "unix://" URI, matching how the CLI's --remote flag is documented.
+func DialLocal(addr string) (*Client, error) {
+	addr = strings.TrimPrefix(addr, "unix://")
+
+	dial := func() (net.Conn, error) {
+		return dialLocal(addr)
+	}
+
+	conn, err := dial()
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial %s: %w", addr, err)
+	}
+	conn.Close()
+
+	return &Client{dial: dial}, nil
+}
+
+// Close is a no-op; Client doesn't hold a persistent connection
+// between calls. It exists so Client satisfies the io.Closer-shaped
+// usage the CLI/TUI expect from a remote scanner/killer (defer
+// client.Close()).
+func (c *Client) Close() error {
+	return nil
+}
+
+// call dials a fresh connection, sends req, and decodes a single
+// response.
+func (c *Client) call(req request) (response, error) {
+	conn, err := c.dial()
+	if err != nil {
+		return response{}, err
+	}
+	defer conn.Close()
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return response{}, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	var resp response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return response{}, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.Error != "" {
+		return response{}, fmt.Errorf("%s", resp.Error)
+	}
+	return resp, nil
+}
+
+// GetListeningPorts implements ports.PortSource.
+func (c *Client) GetListeningPorts() ([]models.PortEntry, error) {
+	return c.GetConnections([]string{"LISTENING", "ESTABLISHED"})
+}
+
+// GetConnections implements ports.PortSource.
+func (c *Client) GetConnections(states []string) ([]models.PortEntry, error) {
+	resp, err := c.call(request{Method: methodList, States: states})
+	if err != nil {
+		return nil, fmt.Errorf("remote list failed: %w", err)
+	}
+	return fromWireEntries(resp.Entries), nil
+}
+
+// Kill implements killer.Terminator.
+func (c *Client) Kill(pid int32) killer.Result {
+	resp, err := c.call(request{Method: methodKill, PID: pid})
+	if err != nil {
+		return killer.Result{Success: false, Message: err.Error(), Error: err}
+	}
+	return killer.Result{Success: resp.Success, Message: resp.Message}
+}
+
+// KillByPort terminates every process bound to port.
+func (c *Client) KillByPort(port uint32) killer.Result {
+	resp, err := c.call(request{Method: methodKillByPort, Port: port})
+	if err != nil {
+		return killer.Result{Success: false, Message: err.Error(), Error: err}
+	}
+	return killer.Result{Success: resp.Success, Message: resp.Message}
+}
+
+// CheckAccess reports whether the remote server is running elevated.
+func (c *Client) CheckAccess() (bool, error) {
+	resp, err := c.call(request{Method: methodCheckAccess})
+	if err != nil {
+		return false, fmt.Errorf("remote check_access failed: %w", err)
+	}
+	return resp.Elevated, nil
+}
+
+// WatchEvent describes a single change an open Watch call observed.
+type WatchEvent struct {
+	Type  string // "added", "removed", or "state_changed"
+	Entry models.PortEntry
+}
+
+// Watch opens a long-lived connection and calls onEvent for each
+// added/removed/state-changed event the server observes, until the
+// connection ends or a decode fails.
+func (c *Client) Watch(states []string, interval time.Duration, onEvent func(WatchEvent)) error {
+	conn, err := c.dial()
+	if err != nil {
+		return fmt.Errorf("failed to dial: %w", err)
+	}
+	defer conn.Close()
+
+	req := request{Method: methodWatch, States: states, IntervalSeconds: int64(interval.Seconds())}
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return fmt.Errorf("failed to send watch request: %w", err)
+	}
+
+	dec := json.NewDecoder(conn)
+	for {
+		var resp response
+		if err := dec.Decode(&resp); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if resp.Error != "" {
+			return fmt.Errorf("%s", resp.Error)
+		}
+		if len(resp.Entries) == 0 {
+			continue
+		}
+		onEvent(WatchEvent{Type: resp.Event, Entry: fromWireEntry(resp.Entries[0])})
+	}
+}
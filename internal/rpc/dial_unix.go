@@ -0,0 +1,10 @@
+//go:build !windows
+
+package rpc
+
+import "net"
+
+// dialLocal opens a connection to the daemon's Unix domain socket.
+func dialLocal(addr string) (net.Conn, error) {
+	return net.Dial("unix", addr)
+}
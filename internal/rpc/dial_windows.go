@@ -0,0 +1,15 @@
+//go:build windows
+
+package rpc
+
+import (
+	"context"
+	"net"
+
+	winio "github.com/Microsoft/go-winio"
+)
+
+// dialLocal opens a connection to the daemon's named pipe.
+func dialLocal(addr string) (net.Conn, error) {
+	return winio.DialPipeContext(context.Background(), addr)
+}
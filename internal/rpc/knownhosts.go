@@ -0,0 +1,97 @@
+package rpc
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// KnownHosts implements SSH-style trust-on-first-use certificate
+// pinning: the fingerprint of a server's certificate is recorded the
+// first time it's seen, and every later connection must present the
+// same fingerprint or be rejected.
+type KnownHosts struct {
+	path  string
+	certs map[string]string // host -> hex-encoded SHA-256 fingerprint
+}
+
+// LoadKnownHosts reads ~/.config/portexec/known_hosts, creating an
+// empty set if the file doesn't exist yet.
+func LoadKnownHosts() (*KnownHosts, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve user config dir: %w", err)
+	}
+	path := filepath.Join(dir, "portexec", "known_hosts")
+
+	kh := &KnownHosts{path: path, certs: make(map[string]string)}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return kh, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open known_hosts: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		kh.certs[fields[0]] = fields[1]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read known_hosts: %w", err)
+	}
+
+	return kh, nil
+}
+
+// Fingerprint returns the hex-encoded SHA-256 fingerprint of a
+// certificate's raw DER bytes.
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// Lookup returns the pinned fingerprint for host, and whether one is
+// on record at all.
+func (k *KnownHosts) Lookup(host string) (string, bool) {
+	fp, ok := k.certs[host]
+	return fp, ok
+}
+
+// Pin records host's certificate fingerprint, appending to the
+// known_hosts file.
+func (k *KnownHosts) Pin(host string, cert *x509.Certificate) error {
+	fp := Fingerprint(cert)
+
+	if err := os.MkdirAll(filepath.Dir(k.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config dir: %w", err)
+	}
+
+	f, err := os.OpenFile(k.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open known_hosts: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := fmt.Fprintf(f, "%s %s\n", host, fp); err != nil {
+		return fmt.Errorf("failed to write known_hosts: %w", err)
+	}
+
+	k.certs[host] = fp
+	return nil
+}
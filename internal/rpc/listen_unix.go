@@ -0,0 +1,49 @@
+//go:build !windows
+
+package rpc
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"strconv"
+)
+
+// sharedGroup is the Unix group, in addition to the socket's owner,
+// allowed to connect to a local PortExec socket. This mirrors Docker's
+// root:docker / 0660 model for /var/run/docker.sock: an unprivileged
+// user reaches a daemon running as root by being a member of this
+// group, rather than the socket being owned by root (and therefore
+// unreachable by anyone else, which defeats the entire point of
+// `portexec daemon` — letting an unprivileged client talk to an
+// elevated daemon). An administrator creates the group and adds
+// trusted users to it; if the group doesn't exist, the socket falls
+// back to owner-only access.
+const sharedGroup = "portexec"
+
+// listen opens a Unix domain socket at path, removing any stale socket
+// file left behind by a previous run, and grants access to the socket
+// owner and sharedGroup's members (0660) instead of owner-only.
+func listen(path string) (net.Listener, error) {
+	if err := os.RemoveAll(path); err != nil {
+		return nil, fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+	}
+
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0o660); err != nil {
+		lis.Close()
+		return nil, fmt.Errorf("failed to set socket permissions: %w", err)
+	}
+
+	if g, err := user.LookupGroup(sharedGroup); err == nil {
+		if gid, err := strconv.Atoi(g.Gid); err == nil {
+			_ = os.Chown(path, -1, gid) // best-effort: falls back to owner-only if this fails
+		}
+	}
+
+	return lis, nil
+}
@@ -0,0 +1,25 @@
+//go:build windows
+
+package rpc
+
+import (
+	"net"
+
+	winio "github.com/Microsoft/go-winio"
+)
+
+// sharedPipeSD grants full access (GA) to the pipe's owner (OW) and to
+// all authenticated users (AU), not just the owner, so an unprivileged
+// client can reach a named pipe created by a daemon running as
+// Administrator/SYSTEM — the Windows equivalent of sharedGroup in
+// listen_unix.go. An owner-only ACE here would make `portexec daemon`
+// unreachable by the unprivileged client it exists to serve.
+const sharedPipeSD = "D:P(A;;GA;;;OW)(A;;GA;;;AU)"
+
+// listen opens a named pipe at path (e.g. `\\.\pipe\portexec`), the
+// Windows equivalent of a Unix domain socket.
+func listen(path string) (net.Listener, error) {
+	return winio.ListenPipe(path, &winio.PipeConfig{
+		SecurityDescriptor: sharedPipeSD,
+	})
+}
@@ -0,0 +1,127 @@
+package rpc
+
+import (
+	"fmt"
+	"portexec/internal/models"
+	"time"
+)
+
+// This package implements its own small request/response protocol over
+// net.Conn instead of gRPC/protobuf: a JSON-encoded Request followed by
+// one or more JSON-encoded Responses on the same connection. This
+// keeps the service buildable with the standard library alone — no
+// .proto compilation step, and no generated code to check in or
+// .gitignore.
+
+// method identifies which operation a Request is asking the server to
+// perform.
+type method string
+
+const (
+	methodList        method = "list"
+	methodWatch       method = "watch"
+	methodKill        method = "kill"
+	methodKillByPort  method = "kill_by_port"
+	methodCheckAccess method = "check_access"
+)
+
+// request is sent once by the client as the first message on a
+// connection.
+type request struct {
+	Method method `json:"method"`
+
+	// List/Watch
+	States []string `json:"states,omitempty"`
+
+	// Watch: how often to re-scan and diff. Zero means the server picks
+	// a default.
+	IntervalSeconds int64 `json:"interval_seconds,omitempty"`
+
+	// Kill
+	PID int32 `json:"pid,omitempty"`
+
+	// KillByPort
+	Port uint32 `json:"port,omitempty"`
+}
+
+// response is sent once by the server for every method except Watch,
+// which sends a stream of these instead (one per event, Entries
+// holding a single-element slice).
+type response struct {
+	Entries  []wireEntry `json:"entries,omitempty"`
+	Success  bool        `json:"success,omitempty"`
+	Message  string      `json:"message,omitempty"`
+	Elevated bool        `json:"elevated,omitempty"`
+	Event    string      `json:"event,omitempty"` // Watch only: "added", "removed", "state_changed"
+	Error    string      `json:"error,omitempty"`
+}
+
+// wireEntry mirrors models.PortEntry for JSON transport.
+type wireEntry struct {
+	Protocol     string `json:"protocol"`
+	LocalAddress string `json:"local_address"`
+	Port         uint32 `json:"port"`
+	PID          int32  `json:"pid"`
+	ProcessName  string `json:"process_name"`
+	State        string `json:"state"`
+	ParentPID    int32  `json:"parent_pid"`
+	UptimeSecs   int64  `json:"uptime_seconds"`
+	ExePath      string `json:"exe_path"`
+	IsSystem     bool   `json:"is_system"`
+}
+
+func toWireEntry(e models.PortEntry) wireEntry {
+	return wireEntry{
+		Protocol:     e.Protocol,
+		LocalAddress: e.LocalAddress,
+		Port:         e.Port,
+		PID:          e.PID,
+		ProcessName:  e.ProcessName,
+		State:        e.State,
+		ParentPID:    e.ParentPID,
+		UptimeSecs:   int64(e.Uptime.Seconds()),
+		ExePath:      e.ExePath,
+		IsSystem:     e.IsSystem,
+	}
+}
+
+func toWireEntries(entries []models.PortEntry) []wireEntry {
+	out := make([]wireEntry, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, toWireEntry(e))
+	}
+	return out
+}
+
+func fromWireEntry(e wireEntry) models.PortEntry {
+	return models.PortEntry{
+		Protocol:     e.Protocol,
+		LocalAddress: e.LocalAddress,
+		Port:         e.Port,
+		PID:          e.PID,
+		ProcessName:  e.ProcessName,
+		State:        e.State,
+		ParentPID:    e.ParentPID,
+		Uptime:       durationFromSeconds(e.UptimeSecs),
+		ExePath:      e.ExePath,
+		IsSystem:     e.IsSystem,
+	}
+}
+
+func fromWireEntries(entries []wireEntry) []models.PortEntry {
+	out := make([]models.PortEntry, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, fromWireEntry(e))
+	}
+	return out
+}
+
+func durationFromSeconds(s int64) time.Duration {
+	return time.Duration(s) * time.Second
+}
+
+// watchKey identifies the same logical connection across consecutive
+// snapshots, so Watch can tell added/removed/state-changed apart.
+func watchKey(e models.PortEntry) string {
+	return fmt.Sprintf("%s:%s:%d", e.Protocol, e.LocalAddress, e.PID)
+}
@@ -0,0 +1,219 @@
+// Package rpc exposes ports.PortSource and killer.Terminator over a
+// single service reachable two ways: mutual TLS over TCP, for a remote
+// host across an untrusted network (see Serve/DialTLS, authenticated
+// with a trust-on-first-use prompt on the client side — see
+// KnownHosts), or a Unix domain socket/named pipe, for an unprivileged
+// local client talking to a daemon running elevated (see
+// ServeLocal/DialLocal). Both transports speak the same
+// JSON-over-net.Conn protocol defined in protocol.go, so there is one
+// wire format and one Server implementation to maintain instead of two
+// separate gRPC services.
+package rpc
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"portexec/internal/killer"
+	"portexec/internal/models"
+	"portexec/internal/ports"
+	"time"
+)
+
+// defaultWatchInterval is used when a Watch request doesn't specify
+// IntervalSeconds.
+const defaultWatchInterval = 2 * time.Second
+
+// Server implements the PortExec wire protocol on top of a local
+// PortSource/Terminator pair.
+type Server struct {
+	source   ports.PortSource
+	term     killer.Terminator
+	elevated bool
+}
+
+// NewServer creates a Server backed by source and term. elevated
+// reports whether the server process itself is running with elevated
+// privileges, surfaced via CheckAccess.
+func NewServer(source ports.PortSource, term killer.Terminator, elevated bool) *Server {
+	return &Server{source: source, term: term, elevated: elevated}
+}
+
+// serve accepts connections on lis until it errors, handling each one
+// in its own goroutine.
+func (s *Server) serve(lis net.Listener) error {
+	defer lis.Close()
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			return err
+		}
+		go s.handleConn(conn)
+	}
+}
+
+// handleConn reads a single request from conn and dispatches it. List,
+// Kill, KillByPort, and CheckAccess write one response and close the
+// connection; Watch keeps it open, streaming one response per event.
+func (s *Server) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	var req request
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		return
+	}
+
+	enc := json.NewEncoder(conn)
+
+	switch req.Method {
+	case methodList:
+		entries, err := s.source.GetConnections(req.States)
+		if err != nil {
+			_ = enc.Encode(response{Error: fmt.Sprintf("failed to get connections: %v", err)})
+			return
+		}
+		_ = enc.Encode(response{Success: true, Entries: toWireEntries(entries)})
+
+	case methodKill:
+		result := s.term.Kill(req.PID)
+		_ = enc.Encode(response{Success: result.Success, Message: result.Message})
+
+	case methodKillByPort:
+		_ = enc.Encode(s.killByPort(req.Port))
+
+	case methodCheckAccess:
+		_ = enc.Encode(response{Success: true, Elevated: s.elevated})
+
+	case methodWatch:
+		s.watch(conn, req)
+
+	default:
+		_ = enc.Encode(response{Error: fmt.Sprintf("unknown method %q", req.Method)})
+	}
+}
+
+// killByPort terminates every process bound to port, deduplicated by
+// PID (a process can be bound to the same port on multiple protocols),
+// aggregating the outcome into a single response.
+func (s *Server) killByPort(port uint32) response {
+	entries, err := s.source.GetConnections(nil)
+	if err != nil {
+		return response{Error: fmt.Sprintf("failed to get connections: %v", err)}
+	}
+
+	pidSeen := make(map[int32]bool)
+	var killed, failed int
+	var lastMsg string
+	for _, e := range entries {
+		if e.Port != port || pidSeen[e.PID] {
+			continue
+		}
+		pidSeen[e.PID] = true
+
+		result := s.term.Kill(e.PID)
+		lastMsg = result.Message
+		if result.Success {
+			killed++
+		} else {
+			failed++
+		}
+	}
+
+	if killed == 0 && failed == 0 {
+		return response{Success: false, Message: fmt.Sprintf("no process found on port %d", port)}
+	}
+	return response{Success: failed == 0, Message: fmt.Sprintf("killed %d, failed %d: %s", killed, failed, lastMsg)}
+}
+
+// watch streams added/removed/state-changed events on conn until a
+// write fails (the client disconnected) or a scan errors, by diffing
+// consecutive GetConnections snapshots.
+func (s *Server) watch(conn net.Conn, req request) {
+	interval := time.Duration(req.IntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	enc := json.NewEncoder(conn)
+	prev := make(map[string]models.PortEntry)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		entries, err := s.source.GetConnections(req.States)
+		if err != nil {
+			return
+		}
+
+		cur := make(map[string]models.PortEntry, len(entries))
+		for _, e := range entries {
+			cur[watchKey(e)] = e
+		}
+
+		for key, e := range cur {
+			old, existed := prev[key]
+			var event string
+			switch {
+			case !existed:
+				event = "added"
+			case old.State != e.State:
+				event = "state_changed"
+			default:
+				continue
+			}
+			if err := enc.Encode(response{Event: event, Entries: []wireEntry{toWireEntry(e)}}); err != nil {
+				return
+			}
+		}
+		for key, e := range prev {
+			if _, stillPresent := cur[key]; !stillPresent {
+				if err := enc.Encode(response{Event: "removed", Entries: []wireEntry{toWireEntry(e)}}); err != nil {
+					return
+				}
+			}
+		}
+
+		prev = cur
+		<-ticker.C
+	}
+}
+
+// Serve listens on listenAddr (a TCP address) and serves the PortExec
+// service over mutual TLS, for access from other hosts. It blocks
+// until the listener errors or the process is terminated.
+func Serve(listenAddr, certFile, keyFile string, source ports.PortSource, term killer.Terminator) error {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS key pair: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAnyClientCert,
+		MinVersion:   tls.VersionTLS13,
+	}
+
+	lis, err := tls.Listen("tcp", listenAddr, tlsConfig)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", listenAddr, err)
+	}
+
+	return NewServer(source, term, killer.IsElevated()).serve(lis)
+}
+
+// ServeLocal listens on addr (a Unix domain socket path on Linux/macOS,
+// a named pipe path on Windows — see listen) and serves the PortExec
+// service unauthenticated at the transport level, relying on
+// filesystem/pipe permissions for access control (see listen_unix.go
+// and listen_windows.go). elevated reports whether this process is
+// running with elevated privileges, surfaced via CheckAccess. It
+// blocks until the listener errors or the process is terminated.
+func ServeLocal(addr string, source ports.PortSource, term killer.Terminator, elevated bool) error {
+	lis, err := listen(addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	return NewServer(source, term, elevated).serve(lis)
+}
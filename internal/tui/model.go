@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"portexec/internal/killer"
 	"portexec/internal/models"
+	"portexec/internal/policy"
 	"portexec/internal/ports"
+	"portexec/internal/processes"
+	"portexec/internal/proctree"
 	"portexec/internal/version"
 	"strings"
 	"sync"
@@ -14,6 +17,12 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+// Tab identifies which top-level view is active in the TUI.
+const (
+	tabList  = 0
+	tabGraph = 1
+)
+
 // Model represents the TUI state
 type Model struct {
 	// Data
@@ -39,9 +48,40 @@ type Model struct {
 	// Admin status
 	isAdmin bool
 
-	// Services
-	scanner *ports.Scanner
-	kill    *killer.Killer
+	// Tree view
+	treeMode        bool
+	treeCollapsed   map[int32]bool
+	treeNodes       []*proctree.Node
+	treeFlat        []*proctree.Node
+	killSubtree     bool
+	killSubtreeNode *proctree.Node
+
+	// Graph view
+	activeTab     int
+	history       map[uint32][]int
+	historyPaused bool
+
+	// Live watch mode: auto-refresh on a timer instead of waiting for "r"
+	autoRefresh         bool
+	autoRefreshInterval time.Duration
+
+	// Policy engine: drives auto-kill/alert enforcement and the
+	// policy-match badge shown on matching rows. Kill-time protection of
+	// warn-listed processes lives in killer.Killer (see SetPolicy below),
+	// not here.
+	policyEngine *policy.Engine
+	policyAlert  string
+	showRules    bool
+
+	// Services. scanner/kill are interfaces so a remote rpc.Client can
+	// stand in for the local Scanner/Killer (see NewRemoteModel).
+	// localKill is additionally set when kill is backed by a local
+	// Killer, since tree-wide kills aren't part of the Terminator
+	// interface and have no remote equivalent yet.
+	scanner    ports.PortSource
+	kill       killer.Terminator
+	localKill  *killer.Killer
+	procGetter *processes.Getter
 
 	// Refreshing
 	mu         sync.RWMutex
@@ -51,33 +91,63 @@ type Model struct {
 	selectedEntry models.PortEntry
 }
 
-// InitialModel creates the initial TUI model
+// InitialModel creates the TUI model backed by the local Scanner and
+// Killer.
 func InitialModel() *Model {
-	scanner := ports.NewScanner()
 	kill := killer.NewKiller()
+	return newModel(ports.NewScanner(), kill, kill)
+}
+
+// NewRemoteModel creates a TUI model backed by a remote PortSource and
+// Terminator, such as an rpc.Client dialed against `portexec serve`.
+// Subtree kills are unavailable in this mode, since KillSubtree isn't
+// part of the Terminator interface.
+func NewRemoteModel(source ports.PortSource, term killer.Terminator) *Model {
+	return newModel(source, term, nil)
+}
 
+func newModel(source ports.PortSource, term killer.Terminator, localKill *killer.Killer) *Model {
 	// Check if running as admin
 	isAdmin := killer.IsElevated()
 
+	// Policy engine is optional: no config file yet means no
+	// badges/enforcement, not an error.
+	var policyEngine *policy.Engine
+	if path, err := policy.DefaultPath(); err == nil {
+		if engine, err := policy.Load(path); err == nil {
+			policyEngine = engine
+		}
+	}
+	if localKill != nil {
+		localKill.SetPolicy(policyEngine)
+	}
+
 	return &Model{
-		entries:         []models.PortEntry{},
-		filtered:        []models.PortEntry{},
-		selected:        0,
-		filter:          models.FilterCriteria{},
-		isLoading:       true,
-		showHelp:        false,
-		showKillConfirm: false,
-		showDetails:     false,
-		searchMode:      false,
-		searchQuery:     "",
-		errMsg:          "",
-		successMsg:      "",
-		page:            0,
-		pageSize:        20,
-		isAdmin:         isAdmin,
-		scanner:         scanner,
-		kill:            kill,
-		refreshing:      false,
+		entries:             []models.PortEntry{},
+		filtered:            []models.PortEntry{},
+		selected:            0,
+		filter:              models.FilterCriteria{},
+		isLoading:           true,
+		showHelp:            false,
+		showKillConfirm:     false,
+		showDetails:         false,
+		searchMode:          false,
+		searchQuery:         "",
+		errMsg:              "",
+		successMsg:          "",
+		page:                0,
+		pageSize:            20,
+		isAdmin:             isAdmin,
+		treeCollapsed:       make(map[int32]bool),
+		activeTab:           tabList,
+		history:             make(map[uint32][]int),
+		policyEngine:        policyEngine,
+		autoRefreshInterval: 3 * time.Second,
+		scanner:             source,
+		kill:                term,
+		localKill:           localKill,
+		procGetter:          processes.NewGetter(),
+		refreshing:          false,
 	}
 }
 
@@ -96,10 +166,14 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.mu.Lock()
 		m.entries = msg.entries
 		m.applyFilter()
+		m.rebuildTree()
+		m.recordHistory()
+		m.enforcePolicy()
 		m.isLoading = false
 		m.refreshing = false
+		cmd := m.clearMsgCmd()
 		m.mu.Unlock()
-		return m, nil
+		return m, cmd
 
 	case refreshError:
 		m.mu.Lock()
@@ -107,22 +181,53 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.isLoading = false
 		m.refreshing = false
 		m.mu.Unlock()
+		return m, m.clearMsgCmd()
+
+	case clearMsgTick:
+		m.mu.Lock()
+		m.errMsg = ""
+		m.successMsg = ""
+		m.mu.Unlock()
 		return m, nil
 
+	case autoRefreshTick:
+		if !m.autoRefresh {
+			return m, nil
+		}
+		return m, tea.Batch(m.refresh(), m.autoRefreshCmd())
+
 	case tea.WindowSizeMsg:
 		// Handle window resize if needed
 	}
 
-	// Clear messages after delay
-	if m.errMsg != "" || m.successMsg != "" {
-		go func() {
-			time.Sleep(3 * time.Second)
-			// Note: We can't directly modify model from goroutine
-			// This would need to be handled differently in production
-		}()
+	return m, nil
+}
+
+// clearMsgTick fires a few seconds after errMsg/successMsg is set, so
+// the banner survives a render cycle instead of disappearing the
+// instant View() draws it once.
+type clearMsgTick struct{}
+
+// clearMsgCmd schedules a clearMsgTick if a banner is currently set,
+// or returns nil if there's nothing to clear.
+func (m *Model) clearMsgCmd() tea.Cmd {
+	if m.errMsg == "" && m.successMsg == "" {
+		return nil
 	}
+	return tea.Tick(3*time.Second, func(time.Time) tea.Msg {
+		return clearMsgTick{}
+	})
+}
 
-	return m, nil
+// autoRefreshTick fires on a timer while live watch mode ("W") is on,
+// triggering a refresh without the user pressing "r".
+type autoRefreshTick struct{}
+
+// autoRefreshCmd schedules the next autoRefreshTick.
+func (m *Model) autoRefreshCmd() tea.Cmd {
+	return tea.Tick(m.autoRefreshInterval, func(time.Time) tea.Msg {
+		return autoRefreshTick{}
+	})
 }
 
 // handleKeyPress handles keyboard input
@@ -143,6 +248,17 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) (*Model, tea.Cmd) {
 		return m.handleKillConfirm(msg)
 	}
 
+	// If showing kill-subtree confirmation
+	if m.killSubtree {
+		return m.handleKillSubtreeConfirm(msg)
+	}
+
+	// If showing the rules panel, close on any key
+	if m.showRules {
+		m.showRules = false
+		return m, nil
+	}
+
 	// If showing details
 	if m.showDetails {
 		key := msg.String()
@@ -172,6 +288,62 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) (*Model, tea.Cmd) {
 		m.showHelp = true
 		return m, nil
 
+	case "t":
+		m.treeMode = !m.treeMode
+		if m.treeMode {
+			m.rebuildTree()
+		}
+		return m, nil
+
+	case "tab":
+		if m.activeTab == tabList {
+			m.activeTab = tabGraph
+		} else {
+			m.activeTab = tabList
+		}
+		return m, nil
+
+	case "p":
+		m.historyPaused = !m.historyPaused
+		return m, nil
+
+	case "c":
+		m.history = make(map[uint32][]int)
+		return m, nil
+
+	case "R":
+		m.showRules = true
+		return m, nil
+
+	case "W":
+		m.autoRefresh = !m.autoRefresh
+		if m.autoRefresh {
+			return m, m.autoRefreshCmd()
+		}
+		return m, nil
+
+	case "K":
+		m2, cmd := m.handleKillSubtree()
+		return m2, cmd
+
+	case "+":
+		if m.treeMode {
+			if node := m.selectedTreeNode(); node != nil {
+				delete(m.treeCollapsed, node.PID)
+				m.rebuildTree()
+			}
+		}
+		return m, nil
+
+	case "-":
+		if m.treeMode {
+			if node := m.selectedTreeNode(); node != nil {
+				m.treeCollapsed[node.PID] = true
+				m.rebuildTree()
+			}
+		}
+		return m, nil
+
 	case "up", "w": // vi style - up arrow or w
 		if m.selected > 0 {
 			m.selected--
@@ -179,7 +351,11 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) (*Model, tea.Cmd) {
 		return m, nil
 
 	case "down", "j": // vi style
-		if m.selected < len(m.getCurrentPageEntries())-1 {
+		limit := len(m.getCurrentPageEntries())
+		if m.treeMode {
+			limit = len(m.treeFlat)
+		}
+		if m.selected < limit-1 {
 			m.selected++
 		}
 		return m, nil
@@ -283,7 +459,7 @@ func (m *Model) handleKill() (*Model, tea.Cmd) {
 	pageEntries := m.getCurrentPageEntries()
 	if len(pageEntries) == 0 || m.selected >= len(pageEntries) {
 		m.errMsg = "No process selected"
-		return m, nil
+		return m, m.clearMsgCmd()
 	}
 
 	entry := pageEntries[m.selected]
@@ -309,10 +485,10 @@ func (m *Model) handleKillConfirm(msg tea.KeyMsg) (*Model, tea.Cmd) {
 			result := m.kill.Kill(entry.PID)
 			if result.Success {
 				m.successMsg = result.Message
-				return m, m.refresh()
-			} else {
-				m.errMsg = result.Message
+				return m, tea.Batch(m.refresh(), m.clearMsgCmd())
 			}
+			m.errMsg = result.Message
+			return m, m.clearMsgCmd()
 		}
 
 	case "n", "N", "esc":
@@ -325,6 +501,176 @@ func (m *Model) handleKillConfirm(msg tea.KeyMsg) (*Model, tea.Cmd) {
 	return m, nil
 }
 
+// recordHistory appends the current ESTABLISHED connection count for
+// each listening port onto its ring buffer, trimmed to the last
+// pageSize samples. No-op while collection is paused.
+func (m *Model) recordHistory() {
+	if m.historyPaused {
+		return
+	}
+
+	established := make(map[uint32]int)
+	for _, e := range m.entries {
+		if e.State == "ESTABLISHED" {
+			established[e.Port]++
+		}
+	}
+
+	seen := make(map[uint32]bool)
+	for _, e := range m.entries {
+		if e.State != "LISTENING" || seen[e.Port] {
+			continue
+		}
+		seen[e.Port] = true
+
+		buf := append(m.history[e.Port], established[e.Port])
+		if len(buf) > m.pageSize {
+			buf = buf[len(buf)-m.pageSize:]
+		}
+		m.history[e.Port] = buf
+	}
+}
+
+// enforcePolicy evaluates the loaded policy against the latest entries,
+// auto-killing or alerting as configured. A no-op if no policy file was
+// found at startup. Warn matches aren't acted on here; they're only
+// consulted at kill time, via killer.Killer's own policy check.
+func (m *Model) enforcePolicy() {
+	if m.policyEngine == nil {
+		return
+	}
+
+	for _, match := range m.policyEngine.Evaluate(m.entries) {
+		switch match.Rule.Action {
+		case policy.ActionKill:
+			if match.Entry.IsSystem {
+				continue // IsCriticalProcess is an absolute override
+			}
+			result := m.kill.Kill(match.Entry.PID)
+			if result.Success {
+				m.successMsg = fmt.Sprintf("Policy auto-killed %s (PID %d)", match.Entry.ProcessName, match.Entry.PID)
+			}
+
+		case policy.ActionAlert:
+			m.policyAlert = fmt.Sprintf("Policy matched: %s (PID %d) on port %d", match.Entry.ProcessName, match.Entry.PID, match.Entry.Port)
+			go func(rule *policy.Rule, entry models.PortEntry) {
+				_ = policy.Notify(rule, entry) // best-effort; webhook failures aren't surfaced
+			}(match.Rule, match.Entry)
+
+		case policy.ActionWarn, policy.ActionIgnore:
+			// no-op: warn is enforced at kill time, not here.
+		}
+	}
+}
+
+// rebuildTree rebuilds the process forest and its flattened, visible
+// representation from the current filtered entries.
+func (m *Model) rebuildTree() {
+	builder := proctree.NewBuilder(m.procGetter)
+	m.treeNodes = builder.Build(m.filtered)
+	m.treeFlat = flattenTree(m.treeNodes, m.treeCollapsed)
+	if m.selected >= len(m.treeFlat) {
+		m.selected = 0
+	}
+}
+
+// flattenTree walks roots in pre-order, skipping the children of any
+// node whose PID is marked collapsed.
+func flattenTree(roots []*proctree.Node, collapsed map[int32]bool) []*proctree.Node {
+	var out []*proctree.Node
+	var walk func(n *proctree.Node)
+	walk = func(n *proctree.Node) {
+		out = append(out, n)
+		if collapsed[n.PID] {
+			return
+		}
+		for _, c := range n.Children {
+			walk(c)
+		}
+	}
+	for _, r := range roots {
+		walk(r)
+	}
+	return out
+}
+
+// selectedTreeNode returns the node currently highlighted in tree view.
+func (m *Model) selectedTreeNode() *proctree.Node {
+	if m.selected < 0 || m.selected >= len(m.treeFlat) {
+		return nil
+	}
+	return m.treeFlat[m.selected]
+}
+
+// handleKillSubtree opens the kill-subtree confirmation for the
+// selected tree node.
+func (m *Model) handleKillSubtree() (*Model, tea.Cmd) {
+	if !m.treeMode {
+		return m, nil
+	}
+
+	if m.localKill == nil {
+		m.errMsg = "Kill-subtree is not supported against a remote host"
+		return m, m.clearMsgCmd()
+	}
+
+	node := m.selectedTreeNode()
+	if node == nil {
+		m.errMsg = "No process selected"
+		return m, m.clearMsgCmd()
+	}
+
+	m.killSubtreeNode = node
+	m.killSubtree = true
+	return m, nil
+}
+
+// handleKillSubtreeConfirm handles the kill-subtree confirmation dialog
+func (m *Model) handleKillSubtreeConfirm(msg tea.KeyMsg) (*Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		m.killSubtree = false
+		if m.killSubtreeNode == nil {
+			return m, nil
+		}
+
+		if m.localKill == nil {
+			m.errMsg = "Kill-subtree is not supported against a remote host"
+			m.killSubtreeNode = nil
+			return m, m.clearMsgCmd()
+		}
+
+		results := m.localKill.KillSubtree(m.killSubtreeNode.PID)
+		var failed, killed int
+		var lastErr string
+		for _, r := range results {
+			if r.Success {
+				killed++
+			} else {
+				failed++
+				lastErr = r.Message
+			}
+		}
+
+		if failed > 0 {
+			m.errMsg = fmt.Sprintf("Killed %d process(es), %d failed: %s", killed, failed, lastErr)
+		} else {
+			m.successMsg = fmt.Sprintf("Killed %d process(es) in subtree rooted at PID %d", killed, m.killSubtreeNode.PID)
+		}
+		m.killSubtreeNode = nil
+		return m, tea.Batch(m.refresh(), m.clearMsgCmd())
+
+	case "n", "N", "esc":
+		m.killSubtree = false
+		m.killSubtreeNode = nil
+
+	default:
+		// Ignore other keys
+	}
+
+	return m, nil
+}
+
 // Pagination methods
 func (m *Model) getCurrentPageEntries() []models.PortEntry {
 	start := m.page * m.pageSize
@@ -393,6 +739,12 @@ func (m *Model) View() string {
 		sb.WriteString("\n\n")
 	}
 
+	// Persistent rule-engine alert banner
+	if m.policyAlert != "" {
+		sb.WriteString(warningStyle.Render("⚠ " + m.policyAlert))
+		sb.WriteString("\n\n")
+	}
+
 	// Filter display
 	if !m.filter.IsEmpty() {
 		sb.WriteString(fmt.Sprintf("Filter: [%s]  ", m.filter.Port+m.filter.ProcessName+m.filter.PID))
@@ -421,6 +773,16 @@ func (m *Model) View() string {
 		return m.renderKillConfirm()
 	}
 
+	// Kill-subtree confirmation overlay
+	if m.killSubtree {
+		return m.renderKillSubtreeConfirm()
+	}
+
+	// Rules panel
+	if m.showRules {
+		return m.renderRules()
+	}
+
 	// Details overlay
 	if m.showDetails {
 		return m.renderDetails()
@@ -432,35 +794,98 @@ func (m *Model) View() string {
 		sb.WriteString(" (Esc to cancel)\n\n")
 	}
 
-	// Error message
+	// Error message (cleared by a clearMsgTick scheduled when it was set)
 	if m.errMsg != "" {
 		sb.WriteString(errorStyle.Render(m.errMsg))
 		sb.WriteString("\n\n")
-		m.errMsg = "" // Clear after displaying
 	}
 
-	// Success message
+	// Success message (cleared by a clearMsgTick scheduled when it was set)
 	if m.successMsg != "" {
 		sb.WriteString(successStyle.Render(m.successMsg))
 		sb.WriteString("\n\n")
-		m.successMsg = "" // Clear after displaying
-	}
-
-	// Table header
-	sb.WriteString(tableHeaderStyle.Render(
-		fmt.Sprintf("%s %s %s %s %s",
-			padRight("PROTO", 5),
-			padRight("PORT", 6),
-			padRight("PID", 6),
-			padRight("PROCESS", 20),
-			"STATE"),
-	))
+	}
+
+	switch {
+	case m.activeTab == tabGraph:
+		sb.WriteString(m.renderGraph())
+	case m.treeMode:
+		sb.WriteString(m.renderTree())
+	default:
+		// Table header
+		sb.WriteString(tableHeaderStyle.Render(
+			fmt.Sprintf("%s %s %s %s %s",
+				padRight("PROTO", 5),
+				padRight("PORT", 6),
+				padRight("PID", 6),
+				padRight("PROCESS", 20),
+				"STATE"),
+		))
+		sb.WriteString("\n")
+
+		// Table rows
+		pageEntries := m.getCurrentPageEntries()
+		for i, entry := range pageEntries {
+			row := m.renderRow(entry)
+			if i == m.selected {
+				sb.WriteString(selectedRowStyle.Render(row))
+			} else {
+				sb.WriteString(row)
+			}
+			sb.WriteString("\n")
+		}
+
+		if len(m.filtered) == 0 {
+			sb.WriteString(emptyStyle.Render("No ports found"))
+			sb.WriteString("\n")
+		}
+	}
+
+	// Footer
 	sb.WriteString("\n")
+	switch {
+	case m.activeTab == tabGraph:
+		pauseHint := "[p] Pause"
+		if m.historyPaused {
+			pauseHint = "[p] Resume"
+		}
+		sb.WriteString(footerStyle.Render(
+			fmt.Sprintf("[Tab] List view %s [c] Clear history [r] Refresh [q] Quit", pauseHint),
+		))
+	case m.treeMode:
+		sb.WriteString(footerStyle.Render(
+			"[↑/↓] Navigate [+/-] Expand/Collapse [K] Kill subtree [t] Table view [Tab] Graph view [r] Refresh [q] Quit",
+		))
+	default:
+		sb.WriteString(footerStyle.Render(
+			"[↑/↓] Navigate [PgUp/PgDn] Page [k] Kill [t] Tree view [Tab] Graph view [r] Refresh [/] Filter [h] Help [q] Quit",
+		))
+	}
 
-	// Table rows
-	pageEntries := m.getCurrentPageEntries()
-	for i, entry := range pageEntries {
-		row := m.renderRow(entry)
+	return sb.String()
+}
+
+// renderTree renders the process-tree view, indenting each node by its
+// depth and drawing box-drawing connectors between parent and child.
+func (m *Model) renderTree() string {
+	var sb strings.Builder
+
+	sb.WriteString(tableHeaderStyle.Render("PROCESS TREE"))
+	sb.WriteString("\n")
+
+	if len(m.treeFlat) == 0 {
+		sb.WriteString(emptyStyle.Render("No ports found"))
+		sb.WriteString("\n")
+		return sb.String()
+	}
+
+	depth := make(map[int32]int)
+	for _, root := range m.treeNodes {
+		markDepth(root, 0, depth)
+	}
+
+	for i, n := range m.treeFlat {
+		row := m.renderTreeRow(n, depth[n.PID])
 		if i == m.selected {
 			sb.WriteString(selectedRowStyle.Render(row))
 		} else {
@@ -469,20 +894,109 @@ func (m *Model) View() string {
 		sb.WriteString("\n")
 	}
 
-	if len(m.filtered) == 0 {
-		sb.WriteString(emptyStyle.Render("No ports found"))
+	return sb.String()
+}
+
+// markDepth records the depth of n and every descendant into depth.
+func markDepth(n *proctree.Node, level int, depth map[int32]int) {
+	depth[n.PID] = level
+	for _, c := range n.Children {
+		markDepth(c, level+1, depth)
+	}
+}
+
+// renderTreeRow renders a single process-tree row.
+func (m *Model) renderTreeRow(n *proctree.Node, depth int) string {
+	prefix := strings.Repeat("  ", depth)
+	if depth > 0 {
+		prefix += "└─ "
+	}
+
+	toggle := " "
+	if len(n.Children) > 0 {
+		if m.treeCollapsed[n.PID] {
+			toggle = "+"
+		} else {
+			toggle = "-"
+		}
+	}
+
+	label := fmt.Sprintf("%s[%s] %s (PID %d)", prefix, toggle, n.Name, n.PID)
+	if n.Entry != nil {
+		label += fmt.Sprintf(" — %s/%d %s", n.Entry.Protocol, n.Entry.Port, n.Entry.State)
+	}
+	return label
+}
+
+// renderGraph renders the connection-count-over-time view: one row per
+// listening port with an ASCII sparkline of ESTABLISHED connection
+// counts sampled on each refresh.
+func (m *Model) renderGraph() string {
+	var sb strings.Builder
+
+	sb.WriteString(tableHeaderStyle.Render("CONNECTION GRAPH"))
+	if m.historyPaused {
+		sb.WriteString("  ")
+		sb.WriteString(warningStyle.Render("[paused]"))
+	}
+	sb.WriteString("\n\n")
+
+	seen := make(map[uint32]bool)
+	var listening []models.PortEntry
+	for _, e := range m.filtered {
+		if e.State == "LISTENING" && !seen[e.Port] {
+			seen[e.Port] = true
+			listening = append(listening, e)
+		}
+	}
+
+	if len(listening) == 0 {
+		sb.WriteString(emptyStyle.Render("No listening ports"))
 		sb.WriteString("\n")
+		return sb.String()
 	}
 
-	// Footer
-	sb.WriteString("\n")
-	sb.WriteString(footerStyle.Render(
-		"[↑/↓] Navigate [PgUp/PgDn] Page [k] Kill [r] Refresh [/] Filter [h] Help [q] Quit",
-	))
+	for _, e := range listening {
+		buf := m.history[e.Port]
+
+		var current, peak int
+		if len(buf) > 0 {
+			current = buf[len(buf)-1]
+		}
+		for _, v := range buf {
+			if v > peak {
+				peak = v
+			}
+		}
+
+		sb.WriteString(fmt.Sprintf("%s %s [%s] %d/%d\n",
+			padRight(fmt.Sprintf("%d", e.Port), 6),
+			padRight(truncate(e.ProcessName, 18), 20),
+			sparkline(buf),
+			current, peak))
+	}
 
 	return sb.String()
 }
 
+// renderKillSubtreeConfirm renders the kill-subtree confirmation dialog.
+func (m *Model) renderKillSubtreeConfirm() string {
+	if m.killSubtreeNode == nil {
+		m.killSubtree = false
+		return ""
+	}
+	node := m.killSubtreeNode
+
+	var sb strings.Builder
+	sb.WriteString(criticalWarningStyle.Render("Kill entire process subtree?"))
+	sb.WriteString("\n\n")
+	sb.WriteString(fmt.Sprintf("This will terminate %s (PID %d) and all of its descendants,\nleaf-to-root. The operation aborts entirely if any process in the\nsubtree is a critical system process.\n", node.Name, node.PID))
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("%s  %s", confirmKeyStyle.Render("[Y] Yes"), confirmKeyCancelStyle.Render("[N] Cancel")))
+
+	return confirmOverlayStyle.Render(sb.String())
+}
+
 // renderRow renders a single table row
 func (m *Model) renderRow(entry models.PortEntry) string {
 	stateStyle := getStateStyle(entry.State)
@@ -494,12 +1008,20 @@ func (m *Model) renderRow(entry models.PortEntry) string {
 		protocolStyle = protocolUDPStyle
 	}
 
-	return fmt.Sprintf("%s %s %s %s %s",
+	badge := ""
+	if m.policyEngine != nil {
+		if _, ok := m.policyEngine.MatchOne(entry); ok {
+			badge = " " + policyBadgeStyle.Render("[policy]")
+		}
+	}
+
+	return fmt.Sprintf("%s %s %s %s %s%s",
 		protocolStyle.Render(padRight(entry.Protocol, 5)),
 		padRight(fmt.Sprintf("%d", entry.Port), 6),
 		padRight(fmt.Sprintf("%d", entry.PID), 6),
 		padRight(truncate(entry.ProcessName, 18), 20),
 		stateStyle.Render(entry.State),
+		badge,
 	)
 }
 
@@ -525,7 +1047,15 @@ func (m *Model) renderHelp() string {
 		{"PgUp/PgDn", "Change page"},
 		{"Enter", "View process details"},
 		{"k", "Kill selected process"},
+		{"t", "Toggle process-tree view"},
+		{"+/-", "Expand/collapse tree node"},
+		{"K", "Kill selected subtree (tree view)"},
+		{"Tab", "Toggle connection graph view"},
+		{"p", "Pause/resume graph history"},
+		{"c", "Clear graph history"},
+		{"R", "Show active rules panel"},
 		{"r", "Refresh port list"},
+		{"W", "Toggle live auto-refresh (watch mode)"},
 		{"/", "Search/filter mode"},
 		{"h", "Show this help"},
 		{"q", "Quit"},
@@ -542,6 +1072,42 @@ func (m *Model) renderHelp() string {
 	return helpOverlayStyle.Render(sb.String())
 }
 
+// renderRules renders the active-rules panel, listing each loaded rule
+// alongside how many times it has matched.
+func (m *Model) renderRules() string {
+	var sb strings.Builder
+
+	sb.WriteString(helpTitleStyle.Render("Active Rules"))
+	sb.WriteString("\n\n")
+
+	if m.policyEngine == nil || len(m.policyEngine.Rules) == 0 {
+		sb.WriteString(helpDescStyle.Render("No rules loaded (~/.portexec/policy.yaml)."))
+		sb.WriteString("\n")
+	} else {
+		for i, rule := range m.policyEngine.Rules {
+			sb.WriteString(fmt.Sprintf("  %s port=%-12s process=%-16s action=%-7s hits=%d\n",
+				helpKeyStyle.Render(fmt.Sprintf("#%d", i+1)),
+				orDash(rule.Port),
+				orDash(rule.ProcessNameRegex),
+				rule.Action,
+				m.policyEngine.HitCount(i)))
+		}
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(helpCloseStyle.Render("Press any key to close"))
+
+	return helpOverlayStyle.Render(sb.String())
+}
+
+// orDash renders an empty predicate as a placeholder dash.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
 // renderKillConfirm renders the kill confirmation dialog
 func (m *Model) renderKillConfirm() string {
 	pageEntries := m.getCurrentPageEntries()
@@ -752,6 +1318,10 @@ var (
 				Foreground(brandPurple).
 				Bold(true)
 
+	policyBadgeStyle = lipgloss.NewStyle().
+				Foreground(brandYellow).
+				Bold(true)
+
 	// Help overlay
 	helpOverlayStyle = lipgloss.NewStyle().
 				Width(55).
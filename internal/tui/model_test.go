@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"portexec/internal/killer"
+	"portexec/internal/models"
+)
+
+// fakeSource is a ports.PortSource stub that never touches the real
+// network/process table, so Model tests can drive Update deterministically.
+type fakeSource struct{}
+
+func (fakeSource) GetListeningPorts() ([]models.PortEntry, error)      { return nil, nil }
+func (fakeSource) GetConnections([]string) ([]models.PortEntry, error) { return nil, nil }
+
+// fakeTerminator is a killer.Terminator stub; none of these tests kill anything.
+type fakeTerminator struct{}
+
+func (fakeTerminator) Kill(int32) killer.Result { return killer.Result{Success: true} }
+
+func newTestModel() *Model {
+	return NewRemoteModel(fakeSource{}, fakeTerminator{})
+}
+
+// TestUpdateClearMsgTick verifies that a refreshError sets the error
+// banner and schedules a clearMsgTick, and that the tick clears it.
+func TestUpdateClearMsgTick(t *testing.T) {
+	m := newTestModel()
+
+	_, cmd := m.Update(refreshError{err: errors.New("scan failed")})
+	if m.errMsg != "scan failed" {
+		t.Fatalf("errMsg = %q, want %q", m.errMsg, "scan failed")
+	}
+	if cmd == nil {
+		t.Fatal("Update(refreshError) returned a nil cmd, want a scheduled clearMsgTick")
+	}
+
+	_, cmd = m.Update(clearMsgTick{})
+	if m.errMsg != "" {
+		t.Fatalf("errMsg = %q after clearMsgTick, want empty", m.errMsg)
+	}
+	if cmd != nil {
+		t.Fatalf("Update(clearMsgTick) returned a non-nil cmd, want nil")
+	}
+}
+
+// TestUpdateRefreshResult verifies a successful refresh stores the
+// entries and clears the loading state.
+func TestUpdateRefreshResult(t *testing.T) {
+	m := newTestModel()
+	m.isLoading = true
+
+	entries := []models.PortEntry{{Protocol: "TCP", Port: 8080, PID: 1, State: "LISTENING"}}
+	_, _ = m.Update(refreshResult{entries: entries})
+
+	if m.isLoading {
+		t.Error("isLoading still true after refreshResult")
+	}
+	if len(m.entries) != 1 || m.entries[0].Port != 8080 {
+		t.Errorf("entries = %+v, want the single refreshed entry", m.entries)
+	}
+}
+
+// TestClearMsgCmdNoBanner verifies clearMsgCmd doesn't schedule a tick
+// when there's nothing to clear.
+func TestClearMsgCmdNoBanner(t *testing.T) {
+	m := newTestModel()
+	if cmd := m.clearMsgCmd(); cmd != nil {
+		t.Fatal("clearMsgCmd returned a non-nil cmd with no banner set")
+	}
+
+	m.successMsg = "killed pid 1"
+	if cmd := m.clearMsgCmd(); cmd == nil {
+		t.Fatal("clearMsgCmd returned nil with a banner set")
+	}
+}
+
+// TestAutoRefreshCmdInterval sanity-checks the scheduled tick fires
+// against the model's configured interval, not a hardcoded constant.
+func TestAutoRefreshCmdInterval(t *testing.T) {
+	m := newTestModel()
+	m.autoRefreshInterval = time.Millisecond
+
+	cmd := m.autoRefreshCmd()
+	if cmd == nil {
+		t.Fatal("autoRefreshCmd returned nil")
+	}
+	start := time.Now()
+	msg := cmd()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("autoRefreshCmd's tea.Cmd took %v, want about the configured %v", elapsed, m.autoRefreshInterval)
+	}
+	if _, ok := msg.(autoRefreshTick); !ok {
+		t.Fatalf("autoRefreshCmd's tea.Cmd produced %T, want autoRefreshTick", msg)
+	}
+}
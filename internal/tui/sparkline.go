@@ -0,0 +1,35 @@
+package tui
+
+import "strings"
+
+// sparkBars are the block-drawing characters used to plot values,
+// lowest to highest. A minimal, vendored stand-in for a full
+// asciigraph-style plotter since we only ever need a single line.
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders values as a single line of block characters scaled
+// against the maximum value in the series.
+func sparkline(values []int) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	max := 0
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		if max == 0 {
+			sb.WriteRune(sparkBars[0])
+			continue
+		}
+		idx := v * (len(sparkBars) - 1) / max
+		sb.WriteRune(sparkBars[idx])
+	}
+
+	return sb.String()
+}